@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// canAssign reports whether a user can be assigned issues in a repository.
+// Answers are cached per repository and user for the rest of the run.
+func (ic *IssueCreator) canAssign(repo, user string) (bool, error) {
+	key := repo + "/" + user
+	ic.assignableMu.Lock()
+	ok, cached := ic.assignable[key]
+	ic.assignableMu.Unlock()
+	if cached {
+		return ok, nil
+	}
+
+	ok, _, err := ic.issues.IsAssignee(ic.ctx, ic.org, repo, user)
+	if err != nil {
+		return false, fmt.Errorf("failed to check assignee %q in %s/%s: %w", user, ic.org, repo, err)
+	}
+
+	ic.assignableMu.Lock()
+	if ic.assignable == nil {
+		ic.assignable = map[string]bool{}
+	}
+	ic.assignable[key] = ok
+	ic.assignableMu.Unlock()
+	return ok, nil
+}
+
+// checkAssignees splits assignees into those that can and cannot be assigned in a repository
+func (ic *IssueCreator) checkAssignees(repo string, assignees []string) (valid, invalid []string, err error) {
+	for _, user := range assignees {
+		ok, err := ic.canAssign(repo, user)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			valid = append(valid, user)
+		} else {
+			invalid = append(invalid, user)
+		}
+	}
+	return valid, invalid, nil
+}
+
+// mentionList formats users as "@a, @b"
+func mentionList(users []string) string {
+	return "@" + strings.Join(users, ", @")
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// authFailureLimit is the number of authentication failures in a row after which a
+// run is aborted, unless --continue-on-auth-error is set
+const authFailureLimit = 3
+
+// isAuthError reports whether err means the token itself was rejected, such as a 401
+// for a revoked or expired token or a missing SSO authorization; unlike other failures
+// these repeat for every remaining repository
+func isAuthError(err error) bool {
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	var sso *SSOError
+	return errors.As(err, &sso)
+}
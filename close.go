@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var closeCmd = &cobra.Command{
+	Use:   "close",
+	Short: "Close issues across repositories",
+	Long: `Close issues across repositories.
+Either close the issue with --issue-number in each repository, or close every open issue
+carrying --label, optionally only those created longer ago than --older-than.`,
+	RunE: runClose,
+}
+
+// parseAge parses a duration that may also be given in days ("30d") or weeks ("2w")
+func parseAge(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: use Go syntax like 72h or days/weeks like 30d, 2w", value)
+	}
+	return d, nil
+}
+
+// ListStaleIssues returns the open issues with a label that were created before cutoff.
+// Pull requests are left out. A zero cutoff returns all open issues with the label.
+func (ic *IssueCreator) ListStaleIssues(repo, label string, cutoff time.Time) ([]*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{label},
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: ic.listOptions(),
+	}
+
+	var issues []*github.Issue
+	for {
+		page, resp, err := ic.issues.ListByRepo(ic.ctx, ic.org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues in %s/%s: %w", ic.org, repo, err)
+		}
+
+		for _, issue := range page {
+			// Issues are sorted oldest first, so the rest are newer than the cutoff
+			if !cutoff.IsZero() && !issue.GetCreatedAt().Before(cutoff) {
+				return issues, nil
+			}
+			if !issue.IsPullRequest() {
+				issues = append(issues, issue)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
+
+// CloseIssue closes an issue with the given state reason
+func (ic *IssueCreator) CloseIssue(repo string, number int, reason string) error {
+	state := "closed"
+	req := &github.IssueRequest{State: &state}
+	if reason != "" {
+		req.StateReason = &reason
+	}
+	if _, _, err := ic.issues.Edit(ic.ctx, ic.org, repo, number, req); err != nil {
+		return fmt.Errorf("failed to close %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+	return nil
+}
+
+func runClose(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	numbers, err := issueNumbersFromFlags()
+	if err != nil {
+		return err
+	}
+	label := viper.GetString("label")
+	olderThan := viper.GetString("older-than")
+	reason := viper.GetString("reason")
+	dryRun := viper.GetBool("dry-run")
+
+	if org == "" || (!numbers.isSet() && label == "") {
+		return fmt.Errorf("missing required arguments: --org (or --repo) and one of --issue-number, --numbers-from or --label are required")
+	}
+	if numbers.isSet() && label != "" {
+		return fmt.Errorf("--issue-number or --numbers-from cannot be used together with --label")
+	}
+	if olderThan != "" && label == "" {
+		return fmt.Errorf("--older-than can only be used together with --label")
+	}
+	if reason != "completed" && reason != "not_planned" {
+		return fmt.Errorf("--reason must be completed or not_planned")
+	}
+
+	var cutoff time.Time
+	if olderThan != "" {
+		age, err := parseAge(olderThan)
+		if err != nil {
+			return err
+		}
+		cutoff = time.Now().Add(-age)
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	closed := 0
+	skipped := 0
+	failed := 0
+	closeOne := func(repo string, number int, title string) {
+		fmt.Fprintf(creator.out, "Closing %s/%s#%d %s... ", org, repo, number, title)
+		if dryRun {
+			fmt.Fprintln(creator.out, markOK(), "(dry run)")
+			closed++
+			return
+		}
+		if err := creator.CloseIssue(repo, number, reason); err != nil {
+			fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+			failed++
+			return
+		}
+		fmt.Fprintln(creator.out, markOK())
+		closed++
+	}
+
+	for _, repo := range repoList {
+		if numbers.isSet() {
+			number := numbers.forRepo(repo)
+			if number == 0 {
+				fmt.Fprintf(creator.out, "%s/%s: - (skipped: not in --numbers-from)\n", org, repo)
+				skipped++
+				continue
+			}
+			closeOne(repo, number, "")
+			continue
+		}
+
+		issues, err := creator.ListStaleIssues(repo, label, cutoff)
+		if err != nil {
+			fmt.Fprintf(creator.out, "%s/%s: %s (%v)\n", org, repo, markFail(), err)
+			failed++
+			continue
+		}
+		for _, issue := range issues {
+			closeOne(repo, issue.GetNumber(), fmt.Sprintf("%q (opened %s)", issue.GetTitle(), issue.GetCreatedAt().Format("2006-01-02")))
+		}
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	summary := fmt.Sprintf("Summary: %d closed", closed)
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+	fmt.Fprintf(creator.out, "%s, %d failed\n", summary, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	addRepoFlags(closeCmd)
+	closeCmd.Flags().IntP("issue-number", "n", 0, "Issue number to close in each repository")
+	closeCmd.Flags().String("numbers-from", "", "Close the issue of each repository in this repo: number mapping written by create --numbers-out")
+	closeCmd.Flags().String("label", "", "Close the open issues carrying this label")
+	closeCmd.Flags().String("older-than", "", "With --label, only close issues created longer ago than this, e.g. 72h, 30d or 2w")
+	closeCmd.Flags().String("reason", "not_planned", "State reason for closing: completed or not_planned")
+	closeCmd.Flags().Bool("dry-run", false, "Show which issues would be closed without closing them")
+
+	rootCmd.AddCommand(closeCmd)
+}
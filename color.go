@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// colorOutput enables ANSI colors for the status marks
+var colorOutput bool
+
+const (
+	colorGreen = "\033[32m"
+	colorRed   = "\033[31m"
+	colorReset = "\033[0m"
+)
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// setupColor enables colors when stdout is a terminal, unless --no-color or the
+// NO_COLOR environment variable (https://no-color.org) turns them off
+func setupColor() {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	colorOutput = !viper.GetBool("no-color") && !noColor && os.Getenv("TERM") != "dumb" && stdoutIsTerminal()
+}
+
+// colorize wraps text in an ANSI color when colors are enabled
+func colorize(color, text string) string {
+	if !colorOutput {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// markOK returns the success mark, green on color terminals
+func markOK() string {
+	return colorize(colorGreen, "✓")
+}
+
+// markFail returns the failure mark, red on color terminals
+func markFail() string {
+	return colorize(colorRed, "✗")
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Comment on an existing issue in each repository",
+	Long: `Comment on an existing issue in each repository.
+With --body-dir each repository gets the contents of <repo>.md from that directory,
+falling back to --body for repositories without a file.`,
+	RunE: runComment,
+}
+
+// commentBody returns the comment for a repository: its file in dir when there is one,
+// otherwise fallback
+func commentBody(dir, repo, fallback string) (string, error) {
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, repo+".md"))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to read comment for %s: %w", repo, err)
+		}
+	}
+	return fallback, nil
+}
+
+func runComment(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	numbers, err := issueNumbersFromFlags()
+	if err != nil {
+		return err
+	}
+	body := viper.GetString("body")
+	bodyDir := viper.GetString("body-dir")
+	dryRun := viper.GetBool("dry-run")
+
+	if org == "" || !numbers.isSet() || (body == "" && bodyDir == "") {
+		return fmt.Errorf("missing required arguments: --org (or --repo), --issue-number (or --numbers-from) and --body or --body-dir are required")
+	}
+	if bodyDir != "" {
+		if info, err := os.Stat(bodyDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("--body-dir %s is not a directory", bodyDir)
+		}
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	commented := 0
+	skipped := 0
+	failed := 0
+	for _, repo := range repoList {
+		number := numbers.forRepo(repo)
+		if number == 0 {
+			fmt.Fprintf(creator.out, "%s/%s: - (skipped: not in --numbers-from)\n", org, repo)
+			skipped++
+			continue
+		}
+		fmt.Fprintf(creator.out, "Commenting on %s/%s#%d... ", org, repo, number)
+		comment, err := commentBody(bodyDir, repo, body)
+		switch {
+		case err != nil:
+			fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+			failed++
+			continue
+		case strings.TrimSpace(comment) == "":
+			fmt.Fprintf(creator.out, "- (skipped: no %s.md and no --body)\n", repo)
+			skipped++
+			continue
+		case dryRun:
+			fmt.Fprintln(creator.out, markOK(), "(dry run)")
+			commented++
+			continue
+		}
+
+		if err := creator.CommentOnIssue(repo, number, comment); err != nil {
+			fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+			failed++
+			continue
+		}
+		fmt.Fprintln(creator.out, markOK())
+		commented++
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	fmt.Fprintf(creator.out, "Summary: %d commented, %d skipped, %d failed\n", commented, skipped, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	addRepoFlags(commentCmd)
+	commentCmd.Flags().IntP("issue-number", "n", 0, "Issue number to comment on in each repository")
+	commentCmd.Flags().String("numbers-from", "", "Comment on the issue of each repository in this repo: number mapping written by create --numbers-out")
+	commentCmd.Flags().String("body", "", "Comment text, used for repositories without a file in --body-dir")
+	commentCmd.Flags().String("body-dir", "", "Directory with one <repo>.md comment per repository")
+	commentCmd.Flags().Bool("dry-run", false, "Show which issues would get a comment without posting it")
+
+	rootCmd.AddCommand(commentCmd)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var copyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy an existing issue to many repositories",
+	Long: `Copy an existing issue to many repositories.
+The title, body and labels of the source issue are fetched and a copy is created in each target repository.`,
+	RunE: runCopy,
+}
+
+func runCopy(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	fromRepo := viper.GetString("from-repo")
+	number := viper.GetInt("issue-number")
+
+	if org == "" || fromRepo == "" || number <= 0 {
+		return fmt.Errorf("missing required arguments: --org (or --repo), --from-repo, and --issue-number are required")
+	}
+
+	// The source repository may live outside the target organization
+	fromOwner, fromName := org, fromRepo
+	if owner, name, err := parseRepoRef(fromRepo); err == nil {
+		fromOwner, fromName = owner, name
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	source, _, err := creator.issues.Get(creator.ctx, fromOwner, fromName, number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issue %s/%s#%d: %v", fromOwner, fromName, number, err)
+	}
+	creator.title = source.GetTitle()
+	creator.desc = source.GetBody()
+	creator.labels = []string{}
+	for _, label := range source.Labels {
+		creator.labels = append(creator.labels, label.GetName())
+	}
+	creator.dryRun = viper.GetBool("dry-run")
+	creator.maxRetries = viper.GetInt("max-retries")
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+
+	// Never copy the issue back into its own repository
+	var targets []string
+	for _, repo := range repoList {
+		if fromOwner == org && repo == fromName {
+			continue
+		}
+		targets = append(targets, repo)
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+	if err := checkMaxRepos(len(targets)); err != nil {
+		if !creator.dryRun {
+			return fmt.Errorf("refusing to copy the issue: %w", err)
+		}
+		fmt.Fprintf(creator.out, "Warning: %v before the real run\n", err)
+	}
+
+	fmt.Fprintf(creator.out, "Copying %s/%s#%d to organization: %s\n", fromOwner, fromName, number, org)
+	fmt.Fprintf(creator.out, "Title: %s\n", creator.title)
+	fmt.Fprintf(creator.out, "Repositories: %d\n", len(targets))
+	fmt.Fprintln(creator.out, "---")
+
+	results, success, failed := creator.CreateIssuesInRepositories(targets)
+	creator.printSummary(results, success, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	addRepoFlags(copyCmd)
+	copyCmd.Flags().String("from-repo", "", "Repository holding the source issue, as name in --org or owner/name (required)")
+	copyCmd.Flags().IntP("issue-number", "n", 0, "Number of the source issue (required)")
+	copyCmd.Flags().Bool("dry-run", false, "Show what would be created without calling the API (optional)")
+	copyCmd.Flags().Int("max-repos", defaultMaxRepos, "Refuse to copy the issue into more repositories than this unless --confirm-large is given (0 disables the cap)")
+	copyCmd.Flags().Bool("confirm-large", false, "Copy the issue even when more repositories than --max-repos are targeted")
+	copyCmd.Flags().Int("max-retries", 3, "Maximum retries when GitHub asks to back off with Retry-After")
+
+	rootCmd.AddCommand(copyCmd)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// defaultLabelColor is the color GitHub gives labels created without one
+const defaultLabelColor = "ededed"
+
+// hexColor matches a six digit hex color without the leading "#"
+var hexColor = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// parseLabelMap parses repeatable name=value pairs such as --label-colors bug=d73a4a
+func parseLabelMap(flag string, values []string) (map[string]string, error) {
+	m := map[string]string{}
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --%s %q: expected name=value", flag, value)
+		}
+		m[strings.ToLower(name)] = strings.TrimSpace(v)
+	}
+	return m, nil
+}
+
+// setupCreateLabels enables --create-labels with the colors and descriptions given by
+// --label-colors and --label-descriptions. Names are checked against the plain labels;
+// with label templates the rendered names are only known per repository, so any name
+// is accepted and matched when the label is created.
+func (ic *IssueCreator) setupCreateLabels() error {
+	colors, err := parseLabelMap("label-colors", listValues("label-colors"))
+	if err != nil {
+		return err
+	}
+	descriptions, err := parseLabelMap("label-descriptions", listValues("label-descriptions"))
+	if err != nil {
+		return err
+	}
+
+	for name, c := range colors {
+		if len(ic.labelTemplates) == 0 && !containsFold(ic.labels, name) {
+			return fmt.Errorf("--label-colors has %q, which is not one of --labels", name)
+		}
+		colors[name] = normalizeColor(c)
+		if !hexColor.MatchString(colors[name]) {
+			return fmt.Errorf("invalid color %q for label %q: expected six hex digits like d73a4a", c, name)
+		}
+	}
+	for name := range descriptions {
+		if len(ic.labelTemplates) == 0 && !containsFold(ic.labels, name) {
+			return fmt.Errorf("--label-descriptions has %q, which is not one of --labels", name)
+		}
+	}
+
+	ic.createLabels = true
+	ic.labelColors = colors
+	ic.labelDescriptions = descriptions
+	return nil
+}
+
+// labelDefinition returns the definition used to create a label, taking its color and
+// description from the maps keyed by lower-cased label name
+func (ic *IssueCreator) labelDefinition(name string) LabelDefinition {
+	color, ok := ic.labelColors[strings.ToLower(name)]
+	if !ok {
+		color = defaultLabelColor
+	}
+	return LabelDefinition{Name: name, Color: color, Description: ic.labelDescriptions[strings.ToLower(name)]}
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureLabels creates the issue's labels that a repository does not have yet, with their
+// configured color and description. GitHub would otherwise create them without either.
+func (ic *IssueCreator) ensureLabels(repo string) error {
+	labels, err := ic.renderLabels(repo)
+	if err != nil {
+		return err
+	}
+	existing, err := ic.ListLabels(repo)
+	if err != nil {
+		return err
+	}
+	have := map[string]bool{}
+	for _, label := range existing {
+		have[strings.ToLower(label.GetName())] = true
+	}
+
+	for _, name := range labels {
+		if have[strings.ToLower(name)] {
+			continue
+		}
+		def := ic.labelDefinition(name)
+		label := &github.Label{Name: github.String(def.Name), Color: github.String(def.Color)}
+		if def.Description != "" {
+			label.Description = github.String(def.Description)
+		}
+		if _, _, err := ic.issues.CreateLabel(ic.ctx, ic.org, repo, label); err != nil {
+			return fmt.Errorf("failed to create label %q in %s/%s: %w", def.Name, ic.org, repo, err)
+		}
+	}
+	return nil
+}
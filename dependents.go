@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// dependencyManifests are the files searched for a --depends-on module
+var dependencyManifests = []string{"go.mod", "package.json"}
+
+// DependentRepositories finds the organization repositories whose go.mod or
+// package.json mentions the module, using code search on their default branch
+func (ic *IssueCreator) DependentRepositories(module string) ([]string, error) {
+	var repos []string
+	for _, manifest := range dependencyManifests {
+		found, err := ic.CodeSearchRepositories(fmt.Sprintf("%q filename:%s", module, manifest))
+		if err != nil {
+			return nil, err
+		}
+		repos = mergeLists(repos, found)
+	}
+	return repos, nil
+}
+
+// CodeSearchRepositories returns the organization repositories with files matching a
+// code search query, in the order of their first match and without duplicates
+func (ic *IssueCreator) CodeSearchRepositories(query string) ([]string, error) {
+	var repos []string
+	seen := map[string]bool{}
+	opts := &github.SearchOptions{ListOptions: ic.listOptions()}
+	found := 0
+	for {
+		result, resp, err := ic.searchCode(fmt.Sprintf("%s org:%s", query, ic.org), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search code for %s: %w", query, err)
+		}
+		for _, code := range result.CodeResults {
+			found++
+			name := code.GetRepository().GetName()
+			if !seen[name] {
+				seen[name] = true
+				repos = append(repos, name)
+			}
+		}
+		if resp.NextPage == 0 {
+			if result.GetTotal() > found {
+				fmt.Fprintf(ic.out, "Warning: %d files match %s but the search API returns at most %d\n", result.GetTotal(), query, found)
+			}
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return repos, nil
+}
+
+// searchCode runs one page of a code search, waiting out the search rate limit,
+// which is far lower than the core limit, up to ic.maxRetries times
+func (ic *IssueCreator) searchCode(query string, opts *github.SearchOptions) (*github.CodeSearchResult, *github.Response, error) {
+	for attempt := 0; ; attempt++ {
+		result, resp, err := ic.client.Search.Code(ic.ctx, query, opts)
+		if err == nil {
+			return result, resp, nil
+		}
+
+		var rateErr *github.RateLimitError
+		wait, ok := retryAfter(resp)
+		if errors.As(err, &rateErr) {
+			wait, ok = time.Until(rateErr.Rate.Reset.Time), true
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		if !ok || attempt >= ic.maxRetries {
+			return nil, resp, err
+		}
+		fmt.Fprintf(ic.out, "Search rate limit reached, retrying in %s\n", wait.Round(time.Second))
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxDescriptionSize bounds the size of a description fetched from a URL
+const maxDescriptionSize = 1 << 20
+
+// fetchDescriptionURL downloads a description over HTTP(S) and parses its optional front-matter
+func fetchDescriptionURL(rawURL string, timeout time.Duration) (frontMatter, string, error) {
+	if err := validateAttachments([]string{rawURL}); err != nil {
+		return frontMatter{}, "", fmt.Errorf("invalid --description-url: expected an absolute http or https URL")
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return frontMatter{}, "", fmt.Errorf("failed to fetch description: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return frontMatter{}, "", fmt.Errorf("failed to fetch description from %s: HTTP %d", rawURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDescriptionSize+1))
+	if err != nil {
+		return frontMatter{}, "", fmt.Errorf("failed to read description from %s: %w", rawURL, err)
+	}
+	if len(data) > maxDescriptionSize {
+		return frontMatter{}, "", fmt.Errorf("description at %s exceeds the %d byte limit", rawURL, maxDescriptionSize)
+	}
+
+	meta, body, err := parseFrontMatter(string(data))
+	if err != nil {
+		return frontMatter{}, "", fmt.Errorf("failed to parse description from %s: %w", rawURL, err)
+	}
+	return meta, body, nil
+}
+
+// decodeBase64Description decodes a base64 description in standard or URL encoding,
+// with or without padding. Whitespace such as wrapped lines is ignored.
+func decodeBase64Description(encoded string) (string, error) {
+	encoded = strings.Join(strings.Fields(encoded), "")
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if data, err := enc.DecodeString(encoded); err == nil {
+			return string(data), nil
+		}
+	}
+	return "", fmt.Errorf("--description-base64 is not valid base64")
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// errDiscussionsDisabled reports that a repository does not have Discussions enabled
+var errDiscussionsDisabled = errors.New("discussions are not enabled")
+
+// discussionCategoriesQuery looks up a repository's node ID and discussion categories
+const discussionCategoriesQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    id
+    hasDiscussionsEnabled
+    discussionCategories(first: 100) { nodes { id name } }
+  }
+}`
+
+// discussionCategoriesPage is the part of discussionCategoriesQuery's response that is used
+type discussionCategoriesPage struct {
+	Repository struct {
+		ID                    string `json:"id"`
+		HasDiscussionsEnabled bool   `json:"hasDiscussionsEnabled"`
+		DiscussionCategories  struct {
+			Nodes []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"nodes"`
+		} `json:"discussionCategories"`
+	} `json:"repository"`
+}
+
+// ConvertToDiscussion moves a created issue to a discussion in the named category.
+// The API offers no conversion, so a discussion with the issue's title and body is
+// created, and the issue is closed as not planned with a comment linking to it.
+// It returns errDiscussionsDisabled for repositories without Discussions.
+func (ic *IssueCreator) ConvertToDiscussion(repo string, issue *github.Issue, category string) (string, error) {
+	var page discussionCategoriesPage
+	vars := map[string]interface{}{"owner": ic.org, "name": repo}
+	if err := ic.graphQL(discussionCategoriesQuery, vars, &page); err != nil {
+		return "", fmt.Errorf("failed to look up discussion categories of %s/%s: %w", ic.org, repo, err)
+	}
+	if !page.Repository.HasDiscussionsEnabled {
+		return "", errDiscussionsDisabled
+	}
+
+	categoryID := ""
+	for _, node := range page.Repository.DiscussionCategories.Nodes {
+		if node.Name == category {
+			categoryID = node.ID
+		}
+	}
+	if categoryID == "" {
+		return "", fmt.Errorf("discussion category %q not found in %s/%s", category, ic.org, repo)
+	}
+
+	const mutation = `mutation($repo: ID!, $category: ID!, $title: String!, $body: String!) {
+  createDiscussion(input: {repositoryId: $repo, categoryId: $category, title: $title, body: $body}) {
+    discussion { url }
+  }
+}`
+	var created struct {
+		CreateDiscussion struct {
+			Discussion struct {
+				URL string `json:"url"`
+			} `json:"discussion"`
+		} `json:"createDiscussion"`
+	}
+	vars = map[string]interface{}{
+		"repo":     page.Repository.ID,
+		"category": categoryID,
+		"title":    issue.GetTitle(),
+		"body":     issue.GetBody(),
+	}
+	if err := ic.graphQL(mutation, vars, &created); err != nil {
+		return "", fmt.Errorf("failed to create discussion in %s/%s: %w", ic.org, repo, err)
+	}
+	url := created.CreateDiscussion.Discussion.URL
+
+	if err := ic.CommentOnIssue(repo, issue.GetNumber(), "Moved to a discussion: "+url); err != nil {
+		return url, err
+	}
+	return url, ic.CloseIssue(repo, issue.GetNumber(), "not_planned")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// FindDuplicate returns the open issue in a repository whose title equals the title
+// being created, or nil when there is none
+func (ic *IssueCreator) FindDuplicate(repo string) (*github.Issue, error) {
+	title, err := ic.renderTitle(repo)
+	if err != nil {
+		return nil, err
+	}
+	// The open issues are listed instead of searched: the search API allows far fewer
+	// requests and its index lags behind, missing issues created moments earlier
+	issues, err := ic.ListOpenIssues(repo, func(t string) bool { return t == title })
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicates in %s/%s: %w", ic.org, repo, err)
+	}
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	return issues[0], nil
+}
+
+// dedupLabel derives a short label that is the same for every run with the same
+// title and body, so reruns can find the issues created before
+func dedupLabel(title, body string) string {
+	sum := sha256.Sum256([]byte(title + "\x00" + body))
+	return "dedup:" + hex.EncodeToString(sum[:3])
+}
+
+// findLabeledIssue returns an open issue in a repository carrying the label, or nil.
+// Pull requests carrying the label are not counted.
+func (ic *IssueCreator) findLabeledIssue(repo, label string) (*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{label},
+		ListOptions: ic.listOptions(),
+	}
+	for {
+		issues, resp, err := ic.issues.ListByRepo(ic.ctx, ic.org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for %s in %s/%s: %w", label, ic.org, repo, err)
+		}
+		for _, issue := range issues {
+			if !issue.IsPullRequest() {
+				return issue, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CommentOnIssue adds a comment to an existing issue
+func (ic *IssueCreator) CommentOnIssue(repo string, number int, body string) error {
+	ic.limiter.Wait()
+	_, resp, err := ic.issues.CreateComment(ic.ctx, ic.org, repo, number, &github.IssueComment{Body: &body})
+	if resp != nil {
+		ic.limiter.Update(resp.Rate)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to comment on %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+	return nil
+}
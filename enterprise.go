@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// enterpriseOrgsQuery pages through the organizations of an enterprise
+const enterpriseOrgsQuery = `query($slug: String!, $cursor: String) {
+  enterprise(slug: $slug) {
+    organizations(first: 100, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes { login }
+    }
+  }
+}`
+
+// enterpriseOrgsPage is the part of enterpriseOrgsQuery's response that is used
+type enterpriseOrgsPage struct {
+	Enterprise *struct {
+		Organizations struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []struct {
+				Login string `json:"login"`
+			} `json:"nodes"`
+		} `json:"organizations"`
+	} `json:"enterprise"`
+}
+
+// EnterpriseOrganizations lists the logins of the organizations in an enterprise
+func (ic *IssueCreator) EnterpriseOrganizations(slug string) ([]string, error) {
+	var orgs []string
+	vars := map[string]interface{}{"slug": slug}
+	for {
+		var page enterpriseOrgsPage
+		if err := ic.graphQL(enterpriseOrgsQuery, vars, &page); err != nil {
+			return nil, fmt.Errorf("failed to list organizations of enterprise %s: %w", slug, err)
+		}
+		if page.Enterprise == nil {
+			return nil, fmt.Errorf("enterprise %s not found or not visible to this token", slug)
+		}
+		for _, node := range page.Enterprise.Organizations.Nodes {
+			orgs = append(orgs, node.Login)
+		}
+		if !page.Enterprise.Organizations.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = page.Enterprise.Organizations.PageInfo.EndCursor
+	}
+	return orgs, nil
+}
+
+// EnterpriseRepositories lists the repositories of every organization in an enterprise
+// as owner/name references, applying the repository filters within each organization
+func (ic *IssueCreator) EnterpriseRepositories(slug string, filters []repoFilter) ([]string, error) {
+	orgs, err := ic.EnterpriseOrganizations(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	org := ic.org
+	defer func() { ic.org = org }()
+
+	var refs []string
+	for _, login := range orgs {
+		fmt.Fprintf(ic.out, "Fetching repositories from organization: %s...\n", login)
+		ic.org = login
+		all, err := ic.ListRepositories()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch repositories of %s: %w", login, err)
+		}
+		for _, repo := range applyRepoFilters(all, filters) {
+			refs = append(refs, login+"/"+repo.GetName())
+		}
+	}
+	return refs, nil
+}
+
+// confirmEnterprise asks for the enterprise slug to be typed before issues are created
+// in every organization of it. A matching --confirm-all-orgs skips the prompt, which is
+// the only way to proceed when stdin is not a terminal.
+func confirmEnterprise(slug string, owners, repos int) error {
+	if confirmed := viper.GetString("confirm-all-orgs"); confirmed != "" {
+		if confirmed != slug {
+			return fmt.Errorf("--confirm-all-orgs %s does not match the enterprise %s", confirmed, slug)
+		}
+		return nil
+	}
+	if !stdinIsTerminal() {
+		return fmt.Errorf("confirmation required but stdin is not a terminal; pass --confirm-all-orgs %s to proceed", slug)
+	}
+
+	fmt.Printf("This creates an issue in %d repositories across %d organizations of enterprise %s.\n", repos, owners, slug)
+	fmt.Printf("Type the enterprise slug to continue: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if strings.TrimSpace(line) != slug {
+		return fmt.Errorf("aborted: confirmation did not match %s", slug)
+	}
+	return nil
+}
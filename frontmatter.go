@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stringList is a YAML value given either as a sequence or as a comma-separated string
+type stringList []string
+
+// UnmarshalYAML accepts both "a, b" and [a, b]
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = splitList(node.Value)
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// frontMatter holds the issue metadata at the top of a description file
+type frontMatter struct {
+	Title     string     `yaml:"title"`
+	Labels    stringList `yaml:"labels"`
+	Assignees stringList `yaml:"assignees"`
+	Milestone string     `yaml:"milestone"`
+}
+
+// parseFrontMatter splits a "---" delimited YAML block from the start of content.
+// Content without front-matter is returned unchanged as the body.
+func parseFrontMatter(content string) (frontMatter, string, error) {
+	var meta frontMatter
+
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return meta, content, nil
+	}
+
+	rest := strings.TrimPrefix(normalized, "---\n")
+	end := strings.Index(rest, "\n---\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n---") {
+			return meta, "", fmt.Errorf("front-matter is not closed with a \"---\" line")
+		}
+		end = len(rest) - len("\n---")
+	}
+
+	if err := yaml.Unmarshal([]byte(rest[:end]), &meta); err != nil {
+		return meta, "", fmt.Errorf("invalid front-matter: %w", err)
+	}
+
+	body := ""
+	if end+len("\n---\n") <= len(rest) {
+		body = rest[end+len("\n---\n"):]
+	}
+	return meta, strings.TrimLeft(body, "\n"), nil
+}
+
+// readDescriptionFile reads a body file and its optional front-matter
+func readDescriptionFile(path string) (frontMatter, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return frontMatter{}, "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	meta, body, err := parseFrontMatter(string(data))
+	if err != nil {
+		return frontMatter{}, "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return meta, body, nil
+}
+
+// splitTitleLine takes the first non-empty line of a body as the title, without a leading
+// markdown "# ", and returns it with the rest of the body
+func splitTitleLine(body string) (string, string, error) {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		title := strings.TrimSpace(line)
+		if title == "" {
+			continue
+		}
+		title = strings.TrimSpace(strings.TrimPrefix(title, "# "))
+		if title == "" {
+			break
+		}
+		return title, strings.TrimLeft(strings.Join(lines[i+1:], "\n"), "\r\n"), nil
+	}
+	return "", "", fmt.Errorf("the description has no first line to use as the title")
+}
+
+// mergeLists appends the entries of extra that are not already in base
+func mergeLists(base, extra []string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, item := range append(append([]string{}, base...), extra...) {
+		if item != "" && !seen[item] {
+			seen[item] = true
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
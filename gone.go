@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GoneError reports a repository that answered 410 Gone when creating an issue,
+// either because it was transferred or renamed, or because issues are disabled
+type GoneError struct {
+	Repo string
+
+	// MovedTo is the new owner/name when the repository was transferred or renamed
+	MovedTo string
+
+	// Reason explains why the repository is gone when it did not move
+	Reason string
+}
+
+func (e *GoneError) Error() string {
+	if e.MovedTo != "" {
+		return fmt.Sprintf("repository %s moved to %s", e.Repo, e.MovedTo)
+	}
+	return fmt.Sprintf("repository %s is gone: %s", e.Repo, e.Reason)
+}
+
+// movedWithin returns the new repository name when the repository was renamed
+// without leaving the organization, so the issue can be created at the new location
+func (e *GoneError) movedWithin(org string) (string, bool) {
+	owner, name, ok := strings.Cut(e.MovedTo, "/")
+	if !ok || !strings.EqualFold(owner, org) {
+		return "", false
+	}
+	return name, true
+}
+
+// goneError looks up a repository that answered 410 to find out where it went.
+// Fetching a moved repository follows GitHub's redirect to its new location.
+func (ic *IssueCreator) goneError(repo string, cause error) *GoneError {
+	gone := &GoneError{Repo: ic.org + "/" + repo, Reason: cause.Error()}
+
+	current, _, err := ic.repos.Get(ic.ctx, ic.org, repo)
+	switch {
+	case err != nil:
+		gone.Reason = "the repository no longer exists"
+	case !strings.EqualFold(current.GetFullName(), gone.Repo):
+		gone.MovedTo = current.GetFullName()
+	case !current.GetHasIssues():
+		gone.Reason = "issues are disabled"
+	}
+	return gone
+}
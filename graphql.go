@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// graphQLRequest is the payload sent to the GitHub GraphQL endpoint
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the envelope returned by the GitHub GraphQL endpoint
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQL runs a GraphQL query or mutation and decodes its data into out (which may be nil)
+func (ic *IssueCreator) graphQL(query string, variables map[string]interface{}, out interface{}) error {
+	req, err := ic.client.NewRequest("POST", "graphql", &graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var resp graphQLResponse
+	if _, err := ic.client.Do(ic.ctx, req, &resp); err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("graphql: %s", strings.Join(messages, "; "))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, out)
+}
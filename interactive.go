@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// stdinIsTerminal reports whether standard input is attached to a terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm asks a yes/no question on the terminal; it refuses when stdin is not a terminal
+func confirm(prompt string) (bool, error) {
+	if !stdinIsTerminal() {
+		return false, fmt.Errorf("confirmation required but stdin is not a terminal; pass --yes to proceed")
+	}
+
+	fmt.Printf("%s [y/N]: ", prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+// fuzzyMatch reports whether the characters of pattern appear in order in text, ignoring case
+func fuzzyMatch(pattern, text string) bool {
+	pattern = strings.ToLower(pattern)
+	text = strings.ToLower(text)
+	for _, r := range pattern {
+		i := strings.IndexRune(text, r)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(string(r)):]
+	}
+	return true
+}
+
+// parseSelection turns input like "1,3-5" into indexes of shown, which are 1-based positions
+func parseSelection(input string, shown []int) ([]int, error) {
+	var picked []int
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		start, end := part, part
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			start, end = from, to
+		}
+		first, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		last, err := strconv.Atoi(strings.TrimSpace(end))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		if first < 1 || last > len(shown) || first > last {
+			return nil, fmt.Errorf("selection %q is out of range 1-%d", part, len(shown))
+		}
+		for n := first; n <= last; n++ {
+			picked = append(picked, shown[n-1])
+		}
+	}
+	return picked, nil
+}
+
+// pickRepos lets the user filter and multi-select repositories from a list
+func pickRepos(repos []string, in io.Reader, out io.Writer) ([]string, error) {
+	reader := bufio.NewReader(in)
+	filter := ""
+
+	for {
+		var shown []int
+		for i, repo := range repos {
+			if fuzzyMatch(filter, repo) {
+				shown = append(shown, i)
+			}
+		}
+
+		fmt.Fprintln(out)
+		for n, i := range shown {
+			fmt.Fprintf(out, "%4d) %s\n", n+1, repos[i])
+		}
+		if len(shown) == 0 {
+			fmt.Fprintf(out, "No repositories match %q\n", filter)
+		}
+		fmt.Fprint(out, "Type /text to filter, numbers or ranges to select (e.g. 1,3-5), or 'all': ")
+
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("failed to read selection: %w", err)
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "/"):
+			filter = strings.TrimPrefix(line, "/")
+			continue
+		case line == "all":
+			line = fmt.Sprintf("1-%d", len(shown))
+		case line == "":
+			continue
+		}
+
+		picked, err := parseSelection(line, shown)
+		if err != nil {
+			fmt.Fprintf(out, "%v\n", err)
+			continue
+		}
+
+		seen := map[int]bool{}
+		var selected []string
+		for _, i := range picked {
+			if !seen[i] {
+				seen[i] = true
+				selected = append(selected, repos[i])
+			}
+		}
+		if len(selected) > 0 {
+			return selected, nil
+		}
+	}
+}
@@ -0,0 +1,585 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "Manage labels across repositories",
+}
+
+var labelsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set labels on an existing issue in each repository",
+	Long: `Set labels on an existing issue in each repository.
+The current and desired labels are compared and the additions and removals are printed per repository.
+Changes are only applied when --yes is given.`,
+	RunE: runLabelsSet,
+}
+
+var labelsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a label from each repository",
+	Long: `Delete a label from each repository.
+Deleting a label removes it from every issue and pull request in that repository.
+Repositories that do not have the label are reported as skipped.`,
+	RunE: runLabelsDelete,
+}
+
+var labelsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Make each repository's labels match a definition file",
+	Long: `Make each repository's labels match a definition file.
+The file is a YAML or JSON list of labels with name, color and description.
+Missing labels are created and labels with a different color or description are updated.
+With --prune, labels that are not in the file are deleted.`,
+	RunE: runLabelsSync,
+}
+
+var labelsAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Report which repositories are missing labels",
+	Long: `Report which repositories are missing labels.
+Each repository's labels are listed and compared with --label, ignoring case like GitHub does.
+Run it before a labeled campaign to find repositories that need labels sync first.`,
+	RunE: runLabelsAudit,
+}
+
+// diffLabels returns the labels to add and remove to turn current into desired
+func diffLabels(current, desired []string) (added, removed []string) {
+	currentSet := map[string]bool{}
+	for _, label := range current {
+		currentSet[label] = true
+	}
+	desiredSet := map[string]bool{}
+	for _, label := range desired {
+		desiredSet[label] = true
+		if !currentSet[label] {
+			added = append(added, label)
+		}
+	}
+	for _, label := range current {
+		if !desiredSet[label] {
+			removed = append(removed, label)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// SetIssueLabels shows and optionally applies the label changes for an issue in a repository.
+// Without replace only additions are considered; with replace labels outside desired are removed.
+func (ic *IssueCreator) SetIssueLabels(repo string, number int, desired []string, replace, apply bool) (bool, error) {
+	issue, _, err := ic.issues.Get(ic.ctx, ic.org, repo, number)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch issue %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+
+	var current []string
+	for _, label := range issue.Labels {
+		current = append(current, label.GetName())
+	}
+
+	added, removed := diffLabels(current, desired)
+	if !replace {
+		removed = nil
+	}
+	for _, label := range added {
+		fmt.Fprintf(ic.out, "    + %s\n", label)
+	}
+	for _, label := range removed {
+		fmt.Fprintf(ic.out, "    - %s\n", label)
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Fprintln(ic.out, "    (no changes)")
+		return false, nil
+	}
+	if !apply {
+		return true, nil
+	}
+
+	if replace {
+		_, _, err = ic.issues.ReplaceLabelsForIssue(ic.ctx, ic.org, repo, number, desired)
+	} else {
+		_, _, err = ic.issues.AddLabelsToIssue(ic.ctx, ic.org, repo, number, added)
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to update labels on %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+
+	return true, nil
+}
+
+func runLabelsSet(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	numbers, err := issueNumbersFromFlags()
+	if err != nil {
+		return err
+	}
+	labelList := listFlag("labels")
+	replace := viper.GetBool("replace")
+	apply := viper.GetBool("yes")
+
+	if org == "" || !numbers.isSet() {
+		return fmt.Errorf("missing required arguments: --org (or --repo) and --issue-number (or --numbers-from) are required")
+	}
+	if len(labelList) == 0 && !replace {
+		return fmt.Errorf("--labels is required unless --replace is used to clear labels")
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", labelList, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	changed := 0
+	skipped := 0
+	failed := 0
+	for _, repo := range repoList {
+		number := numbers.forRepo(repo)
+		if number == 0 {
+			fmt.Fprintf(creator.out, "%s/%s: - (skipped: not in --numbers-from)\n", org, repo)
+			skipped++
+			continue
+		}
+		fmt.Fprintf(creator.out, "%s/%s#%d:\n", org, repo, number)
+		hasChanges, err := creator.SetIssueLabels(repo, number, labelList, replace, apply)
+		if err != nil {
+			fmt.Fprintf(creator.out, "    %s (%v)\n", markFail(), err)
+			failed++
+			continue
+		}
+		if hasChanges {
+			changed++
+			if apply {
+				fmt.Fprintln(creator.out, "   ", markOK())
+			}
+		}
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	skippedNote := ""
+	if skipped > 0 {
+		skippedNote = fmt.Sprintf(", %d skipped", skipped)
+	}
+	if apply {
+		fmt.Fprintf(creator.out, "Summary: %d updated%s, %d failed\n", changed, skippedNote, failed)
+	} else {
+		fmt.Fprintf(creator.out, "Summary: %d would change%s, %d failed (re-run with --yes to apply)\n", changed, skippedNote, failed)
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// DeleteLabel deletes a label from a repository; it reports false when the label does not exist
+func (ic *IssueCreator) DeleteLabel(repo, name string) (bool, error) {
+	resp, err := ic.issues.DeleteLabel(ic.ctx, ic.org, repo, name)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to delete label %q from %s/%s: %w", name, ic.org, repo, err)
+	}
+	return true, nil
+}
+
+func runLabelsDelete(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(viper.GetString("name"))
+
+	if org == "" || name == "" {
+		return fmt.Errorf("missing required arguments: --org (or --repo) and --name are required")
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	if !viper.GetBool("yes") {
+		ok, err := confirm(fmt.Sprintf("Delete label %q from %d repositories in %s? This removes it from all issues", name, len(repoList), org))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("aborted")
+		}
+	}
+
+	deleted := 0
+	skipped := 0
+	failed := 0
+	for _, repo := range repoList {
+		fmt.Fprintf(creator.out, "Deleting label %q from %s/%s... ", name, org, repo)
+		found, err := creator.DeleteLabel(repo, name)
+		switch {
+		case err != nil:
+			fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+			failed++
+		case !found:
+			fmt.Fprintln(creator.out, "- (label not found, skipped)")
+			skipped++
+		default:
+			fmt.Fprintln(creator.out, markOK())
+			deleted++
+		}
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	fmt.Fprintf(creator.out, "Summary: %d deleted, %d skipped, %d failed\n", deleted, skipped, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// missingLabels returns the wanted labels that are not in a repository's labels, ignoring case
+func missingLabels(labels []*github.Label, wanted []string) []string {
+	existing := map[string]bool{}
+	for _, label := range labels {
+		existing[strings.ToLower(label.GetName())] = true
+	}
+	var missing []string
+	for _, name := range wanted {
+		if !existing[strings.ToLower(name)] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+func runLabelsAudit(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	wanted := listFlag("label")
+
+	if org == "" || len(wanted) == 0 {
+		return fmt.Errorf("missing required arguments: --org (or --repo) and --label are required")
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	// Fetch the labels in parallel and report them in repository order
+	repoLabels := make([][]*github.Label, len(repoList))
+	errs := make([]error, len(repoList))
+	runPool(len(repoList), viper.GetInt("concurrency"), nil, func(i int) {
+		repoLabels[i], errs[i] = creator.ListLabels(repoList[i])
+	})
+
+	complete := 0
+	failed := 0
+	missingIn := map[string][]string{}
+	for i, repo := range repoList {
+		fmt.Fprintf(creator.out, "%s/%s: ", org, repo)
+		labels, err := repoLabels[i], errs[i]
+		if err != nil {
+			fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+			failed++
+			continue
+		}
+		missing := missingLabels(labels, wanted)
+		if len(missing) == 0 {
+			fmt.Fprintln(creator.out, markOK())
+			complete++
+			continue
+		}
+		fmt.Fprintf(creator.out, "%s missing %s\n", markFail(), strings.Join(missing, ", "))
+		for _, name := range missing {
+			missingIn[name] = append(missingIn[name], repo)
+		}
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	for _, name := range wanted {
+		if repos := missingIn[name]; len(repos) > 0 {
+			fmt.Fprintf(creator.out, "%q missing in %d repositories: %s\n", name, len(repos), strings.Join(repos, ", "))
+		}
+	}
+	fmt.Fprintf(creator.out, "Summary: %d with all labels, %d missing labels, %d failed\n",
+		complete, len(repoList)-complete-failed, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// LabelDefinition describes a label in a sync definition file
+type LabelDefinition struct {
+	Name        string `yaml:"name" json:"name"`
+	Color       string `yaml:"color" json:"color"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// normalizeColor strips a leading "#" and lower-cases a hex color
+func normalizeColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(color), "#"))
+}
+
+// readLabelDefinitions reads a YAML or JSON list of label definitions
+func readLabelDefinitions(path string) ([]LabelDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var defs []LabelDefinition
+	if err := yaml.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	seen := map[string]bool{}
+	for i := range defs {
+		defs[i].Name = strings.TrimSpace(defs[i].Name)
+		defs[i].Color = normalizeColor(defs[i].Color)
+		if defs[i].Name == "" {
+			return nil, fmt.Errorf("failed to parse %s: label %d has no name", path, i+1)
+		}
+		if seen[strings.ToLower(defs[i].Name)] {
+			return nil, fmt.Errorf("failed to parse %s: label %q is defined twice", path, defs[i].Name)
+		}
+		seen[strings.ToLower(defs[i].Name)] = true
+	}
+	return defs, nil
+}
+
+// ListLabels fetches all labels of a repository
+func (ic *IssueCreator) ListLabels(repo string) ([]*github.Label, error) {
+	opts := ic.listOptions()
+
+	var labels []*github.Label
+	for {
+		page, resp, err := ic.issues.ListLabels(ic.ctx, ic.org, repo, &opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels in %s/%s: %w", ic.org, repo, err)
+		}
+		labels = append(labels, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return labels, nil
+}
+
+// SyncLabels creates, updates and optionally deletes labels so that a repository matches defs.
+// Label names are compared case-insensitively, as GitHub does. It returns the number of changes.
+func (ic *IssueCreator) SyncLabels(repo string, defs []LabelDefinition, prune, dryRun bool) (int, error) {
+	existing, err := ic.ListLabels(repo)
+	if err != nil {
+		return 0, err
+	}
+	byName := map[string]*github.Label{}
+	for _, label := range existing {
+		byName[strings.ToLower(label.GetName())] = label
+	}
+
+	changes := 0
+	apply := func(description string, call func() error) error {
+		changes++
+		fmt.Fprintf(ic.out, "    %s\n", description)
+		if dryRun {
+			return nil
+		}
+		return call()
+	}
+
+	for _, def := range defs {
+		desired := &github.Label{Name: &def.Name, Color: &def.Color, Description: &def.Description}
+		current, ok := byName[strings.ToLower(def.Name)]
+		delete(byName, strings.ToLower(def.Name))
+
+		if !ok {
+			err = apply(fmt.Sprintf("+ %s", def.Name), func() error {
+				_, _, err := ic.issues.CreateLabel(ic.ctx, ic.org, repo, desired)
+				return err
+			})
+		} else if current.GetName() != def.Name || normalizeColor(current.GetColor()) != def.Color || current.GetDescription() != def.Description {
+			err = apply(fmt.Sprintf("~ %s", def.Name), func() error {
+				_, _, err := ic.issues.EditLabel(ic.ctx, ic.org, repo, current.GetName(), desired)
+				return err
+			})
+		}
+		if err != nil {
+			return changes, fmt.Errorf("failed to sync label %q in %s/%s: %w", def.Name, ic.org, repo, err)
+		}
+	}
+
+	if prune {
+		extras := make([]string, 0, len(byName))
+		for _, label := range byName {
+			extras = append(extras, label.GetName())
+		}
+		sort.Strings(extras)
+		for _, name := range extras {
+			err := apply(fmt.Sprintf("- %s", name), func() error {
+				_, err := ic.issues.DeleteLabel(ic.ctx, ic.org, repo, name)
+				return err
+			})
+			if err != nil {
+				return changes, fmt.Errorf("failed to delete label %q in %s/%s: %w", name, ic.org, repo, err)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func runLabelsSync(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	file := viper.GetString("file")
+	prune := viper.GetBool("prune")
+	dryRun := viper.GetBool("dry-run")
+
+	if org == "" || file == "" {
+		return fmt.Errorf("missing required arguments: --org (or --repo) and --file are required")
+	}
+
+	defs, err := readLabelDefinitions(file)
+	if err != nil {
+		return err
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	changed := 0
+	failed := 0
+	for _, repo := range repoList {
+		fmt.Fprintf(creator.out, "%s/%s:\n", org, repo)
+		changes, err := creator.SyncLabels(repo, defs, prune, dryRun)
+		switch {
+		case err != nil:
+			fmt.Fprintf(creator.out, "    %s (%v)\n", markFail(), err)
+			failed++
+		case changes == 0:
+			fmt.Fprintln(creator.out, "    (in sync)")
+		default:
+			changed++
+		}
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	if dryRun {
+		fmt.Fprintf(creator.out, "Summary: %d would change, %d failed\n", changed, failed)
+	} else {
+		fmt.Fprintf(creator.out, "Summary: %d updated, %d failed\n", changed, failed)
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	addRepoFlags(labelsSetCmd)
+	labelsSetCmd.Flags().IntP("issue-number", "n", 0, "Issue number to update in each repository (required unless --numbers-from is given)")
+	labelsSetCmd.Flags().String("numbers-from", "", "Update the issue of each repository in this repo: number mapping written by create --numbers-out")
+	labelsSetCmd.Flags().StringArrayP("labels", "l", nil, "Desired labels; repeatable and comma-separated")
+	labelsSetCmd.Flags().Bool("replace", false, "Remove labels that are not in --labels")
+	labelsSetCmd.Flags().BoolP("yes", "y", false, "Apply the changes instead of only showing them")
+
+	addRepoFlags(labelsDeleteCmd)
+	labelsDeleteCmd.Flags().String("name", "", "Name of the label to delete (required)")
+	labelsDeleteCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+
+	addRepoFlags(labelsSyncCmd)
+	labelsSyncCmd.Flags().StringP("file", "f", "", "YAML or JSON file with the desired label definitions (required)")
+	labelsSyncCmd.Flags().Bool("prune", false, "Delete labels that are not in the definition file")
+	labelsSyncCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+
+	addRepoFlags(labelsAuditCmd)
+	labelsAuditCmd.Flags().StringArray("label", nil, "Label every repository should have; repeatable and comma-separated (required)")
+	labelsAuditCmd.Flags().Int("concurrency", 1, "Number of repositories to check in parallel")
+
+	labelsCmd.AddCommand(labelsSetCmd)
+	labelsCmd.AddCommand(labelsDeleteCmd)
+	labelsCmd.AddCommand(labelsSyncCmd)
+	labelsCmd.AddCommand(labelsAuditCmd)
+	rootCmd.AddCommand(labelsCmd)
+}
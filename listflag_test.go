@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestListFlagKeepsSpacesFromEnv(t *testing.T) {
+	t.Setenv("GITISSUEHELPER_LABELS", "help wanted, good first issue,help wanted")
+
+	got := listFlag("labels")
+	want := []string{"help wanted", "good first issue"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listFlag(labels) = %q, want %q", got, want)
+	}
+}
+
+func TestListFlagSplitsConfigList(t *testing.T) {
+	viper.Set("assignees", []interface{}{"alice, bob", "carol"})
+	defer viper.Set("assignees", nil)
+
+	got := listFlag("assignees")
+	want := []string{"alice", "bob", "carol"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listFlag(assignees) = %q, want %q", got, want)
+	}
+}
+
+func TestLabelMapKeepsSpacesFromEnv(t *testing.T) {
+	t.Setenv("GITISSUEHELPER_LABEL_DESCRIPTIONS", "bug=Something is broken, badly")
+
+	got, err := parseLabelMap("label-descriptions", listValues("label-descriptions"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"bug": "Something is broken, badly"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLabelMap = %q, want %q", got, want)
+	}
+}
@@ -2,10 +2,23 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
 	"github.com/google/go-github/v57/github"
 	"github.com/spf13/cobra"
@@ -20,90 +33,1199 @@ type IssueCreator struct {
 	title  string
 	desc   string
 	labels []string
+
+	// issues and repos are the client's Issues and Repositories services;
+	// tests can replace them with fakes
+	issues IssuesAPI
+	repos  RepositoriesAPI
+
+	titlePrefix string
+	titleSuffix string
+
+	// assignees are added to each issue; milestone is a milestone title resolved per repository
+	assignees    []string
+	milestone    string
+	milestones   map[string]int
+	milestonesMu sync.Mutex
+
+	// validateAssignees checks each repository's assignees before creating its issue,
+	// dropping those that cannot be assigned, or skipping the repository when
+	// strictAssignees is set. assignable caches the checks per repository and user.
+	validateAssignees bool
+	strictAssignees   bool
+	assignable        map[string]bool
+	assignableMu      sync.Mutex
+
+	// lock locks each created issue with lockReason so it is read-only. announce is
+	// the shorthand for an off-topic lock; pin also pins announced issues
+	lock       bool
+	lockReason string
+	announce   bool
+	pin        bool
+
+	// addToProject is the node ID of a Projects (v2) board each created issue is added to
+	addToProject string
+
+	// convertToDiscussion is the discussion category created issues are moved to
+	convertToDiscussion string
+
+	// subscribers are users mentioned in a comment on each created issue so they follow it;
+	// users caches whether each one exists, with the lookup error of unknown users
+	subscribers []string
+	users       map[string]error
+	usersMu     sync.Mutex
+
+	// assigneeRotation assigns each issue to the next of these users in turn
+	assigneeRotation []string
+
+	// teamAssignees are team slugs mentioned in the body of each issue
+	teamAssignees []string
+
+	// tasks are checklist items appended to each body in a Tasks section
+	tasks []string
+
+	// attachments are URLs listed in an Attachments section of each body
+	attachments []string
+
+	// concurrency is the number of repositories processed in parallel; limiter
+	// keeps all workers within the API rate limit together
+	concurrency int
+	limiter     rateLimiter
+	outputMu    sync.Mutex
+
+	// pacer spaces out creations by --delay-between across all workers
+	pacer pacer
+
+	// maxRetries bounds how often a rate-limited request is retried
+	maxRetries int
+
+	// maxFailures aborts the run once more repositories than this have failed; negative means unlimited
+	maxFailures int
+
+	// continueOnAuthError keeps going after authFailureLimit authentication failures in a row
+	continueOnAuthError bool
+
+	// perPage is the page size used by every paginated list call
+	perPage int
+
+	// out receives all progress and summary output; it defaults to os.Stdout
+	out io.Writer
+
+	// listConcurrency is how many pages of organization repositories are fetched in parallel
+	listConcurrency int
+
+	// onlyIfMissing and onlyIfPresent skip repositories based on whether a path exists
+	onlyIfMissing string
+	onlyIfPresent string
+
+	// skipDuplicates skips repositories with an open issue of the same title;
+	// commentOnExisting comments on that issue instead of skipping it
+	skipDuplicates    bool
+	commentOnExisting string
+
+	// dedupLabel is a label derived from the title and body; repositories with an
+	// open issue carrying it are skipped
+	dedupLabel string
+
+	// createLabels creates the issue's labels before the issue where they are missing, with
+	// labelColors and labelDescriptions keyed by lower-cased label name
+	createLabels      bool
+	labelColors       map[string]string
+	labelDescriptions map[string]string
+
+	// dryRun reports what would be created without calling the API;
+	// simulateFailures is the percentage of dry-run repositories marked as failed
+	dryRun           bool
+	simulateFailures float64
+
+	// validate checks labels, assignees and milestone per repository during a dry run
+	validate bool
+
+	// truncateBody cuts bodies over GitHub's length limit instead of failing
+	truncateBody bool
+
+	// recordRequests keeps the create request of each repository during a dry run
+	recordRequests bool
+
+	// requireWrite skips repositories where the token has less than triage access
+	requireWrite bool
+
+	// titleTemplate and bodyTemplate render the title and description per repository
+	// when --template is set; runStart formatted with dateFormat is their .Date
+	titleTemplate *template.Template
+	bodyTemplate  *template.Template
+
+	// labelTemplates are the --labels entries rendered per repository next to labels
+	labelTemplates []*template.Template
+
+	// fetchRepoInfo fetches each repository's details for .Language and .Topics in
+	// templates; repoInfo caches them per repository
+	fetchRepoInfo bool
+	repoInfo      map[string]*github.Repository
+	repoInfoMu    sync.Mutex
+	runStart      time.Time
+	dateFormat    string
+}
+
+// ClientOptions configures the HTTP transport used to talk to the GitHub API
+type ClientOptions struct {
+	// CACertFile is a PEM file with additional root certificates to trust
+	CACertFile string
+
+	// PerPage is the page size for paginated list calls; GitHub caps it at 100
+	PerPage int
+
+	// Trace logs every HTTP request and response to stderr
+	Trace bool
+
+	// UserAgent replaces the default gitissuehelper/<version> User-Agent header
+	UserAgent string
+
+	// DialTimeout, KeepAlive and TLSHandshakeTimeout replace the transport's defaults when set
+	DialTimeout         time.Duration
+	KeepAlive           time.Duration
+	TLSHandshakeTimeout time.Duration
+
+	// ResponseHeaderTimeout limits the wait for a response after the request was sent; zero waits forever
+	ResponseHeaderTimeout time.Duration
 }
 
+// maxPerPage is the largest page size the GitHub API accepts
+const maxPerPage = 100
+
 // NewIssueCreator creates a new IssueCreator instance
-func NewIssueCreator(token, org, title, desc string, labels []string) (*IssueCreator, error) {
+func NewIssueCreator(token, org, title, desc string, labels []string, opts ClientOptions) (*IssueCreator, error) {
 	if token == "" {
 		return nil, fmt.Errorf("GitHub token is required. Set GITHUB_TOKEN env var or use --token flag")
 	}
 
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+	ctx := context.Background()
+	client, err := newGitHubClient(ctx, token, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	perPage := opts.PerPage
+	if perPage <= 0 || perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	return &IssueCreator{
+		client:  client,
+		issues:  client.Issues,
+		repos:   client.Repositories,
+		ctx:     ctx,
+		org:     org,
+		title:   title,
+		desc:    desc,
+		labels:  labels,
+		perPage: perPage,
+		out:     os.Stdout,
+
+		maxRetries:  3,
+		maxFailures: -1,
+	}, nil
+}
+
+// newGitHubClient creates a GitHub API client authenticated with the given token.
+// The transport honors HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+func newGitHubClient(ctx context.Context, token string, opts ClientOptions) (*github.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if opts.DialTimeout != 0 || opts.KeepAlive != 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if opts.DialTimeout != 0 {
+			dialer.Timeout = opts.DialTimeout
+		}
+		if opts.KeepAlive != 0 {
+			dialer.KeepAlive = opts.KeepAlive
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	if opts.TLSHandshakeTimeout != 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+	transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+
+	if opts.CACertFile != "" {
+		pem, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in %s", opts.CACertFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	// The tracer sits below oauth2 so it sees the final request headers
+	var base http.RoundTripper = transport
+	if opts.Trace {
+		base = &tracingTransport{base: transport, out: os.Stderr}
+	}
+
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	tc := &http.Client{
+		Transport: &oauth2.Transport{Source: ts, Base: base},
+	}
+	client := github.NewClient(tc)
+	client.UserAgent = defaultUserAgent()
+	if opts.UserAgent != "" {
+		client.UserAgent = opts.UserAgent
+	}
+	return client, nil
+}
+
+// clientOptionsFromFlags builds ClientOptions from flags and config
+func clientOptionsFromFlags() ClientOptions {
+	return ClientOptions{
+		CACertFile: viper.GetString("ca-cert"),
+		PerPage:    viper.GetInt("per-page"),
+		Trace:      viper.GetBool("trace"),
+		UserAgent:  viper.GetString("user-agent"),
+
+		DialTimeout:           viper.GetDuration("dial-timeout"),
+		KeepAlive:             viper.GetDuration("keep-alive"),
+		TLSHandshakeTimeout:   viper.GetDuration("tls-handshake-timeout"),
+		ResponseHeaderTimeout: viper.GetDuration("response-header-timeout"),
+	}
+}
+
+// resolveToken returns the token from flags or config, then from an OIDC exchange
+// when one is configured, falling back to GITHUB_TOKEN
+func resolveToken() (string, error) {
+	if token := viper.GetString("token"); token != "" {
+		return token, nil
+	}
+	if exchangeURL := viper.GetString("oidc-exchange-url"); exchangeURL != "" {
+		return oidcToken(exchangeURL)
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token, nil
+	}
+	if runningInActions() {
+		return "", fmt.Errorf("GitHub token is required. In GitHub Actions pass the job token with " +
+			"\"env: GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}\" or use --oidc-exchange-url")
+	}
+	return "", nil
+}
+
+// listOptions returns the pagination options shared by all list calls
+func (ic *IssueCreator) listOptions() github.ListOptions {
+	return github.ListOptions{PerPage: ic.perPage}
+}
+
+// ListRepositories fetches all repositories for an organization with their metadata
+func (ic *IssueCreator) ListRepositories() ([]*github.Repository, error) {
+	opts := &github.RepositoryListByOrgOptions{
+		ListOptions: ic.listOptions(),
+	}
+
+	var repos []*github.Repository
+	for {
+		repoList, resp, err := ic.listRepositoriesPage(opts.Page)
+		if err != nil {
+			return nil, err
+		}
+
+		repos = append(repos, repoList...)
+
+		// The first response tells how many pages there are, so the rest can be fetched in parallel
+		if opts.Page == 0 && ic.listConcurrency > 1 && resp.LastPage > 1 {
+			rest, err := ic.listRepositoryPages(2, resp.LastPage)
+			if err != nil {
+				return nil, err
+			}
+			return uniqueRepositories(append(repos, rest...)), nil
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return repos, nil
+}
+
+// listRepositoriesPage fetches one page of the organization's repositories.
+// Transient failures are retried up to ic.maxRetries times with backoff, so one
+// flaky page does not lose the pages fetched before it.
+func (ic *IssueCreator) listRepositoriesPage(page int) ([]*github.Repository, *github.Response, error) {
+	opts := &github.RepositoryListByOrgOptions{ListOptions: ic.listOptions()}
+	opts.Page = page
+	for attempt := 0; ; attempt++ {
+		repoList, resp, err := ic.repos.ListByOrg(ic.ctx, ic.org, opts)
+		if err == nil {
+			return repoList, resp, nil
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil, fmt.Errorf("organization %q was not found or is not visible to this token", ic.org)
+		}
+
+		wait, ok := transientWait(resp, attempt)
+		if !ok || attempt >= ic.maxRetries {
+			return nil, nil, fmt.Errorf("failed to fetch repositories (page %d): %w", max(page, 1), err)
+		}
+		fmt.Fprintf(os.Stderr, "Fetching page %d failed (%v); retrying in %s\n", max(page, 1), err, wait)
+		time.Sleep(wait)
+	}
+}
+
+// transientWait reports whether a failed request is worth retrying and how long to wait:
+// the Retry-After delay of rate limits, or an exponential backoff of 1s, 2s, 4s, ...
+// up to 30s for network errors and server errors
+func transientWait(resp *github.Response, attempt int) (time.Duration, bool) {
+	if wait, ok := retryAfter(resp); ok {
+		return wait, true
+	}
+	if resp != nil && resp.Response != nil && resp.StatusCode < 500 {
+		return 0, false
+	}
+	if attempt >= 5 {
+		return 30 * time.Second, true
+	}
+	return time.Second << attempt, true
+}
+
+// listRepositoryPages fetches the pages first to last with up to ic.listConcurrency
+// requests in flight and returns their repositories in page order
+func (ic *IssueCreator) listRepositoryPages(first, last int) ([]*github.Repository, error) {
+	pages := make([][]*github.Repository, last-first+1)
+	errs := make([]error, len(pages))
+	runPool(len(pages), ic.listConcurrency, nil, func(i int) {
+		pages[i], _, errs[i] = ic.listRepositoriesPage(first + i)
+	})
+
+	var repos []*github.Repository
+	for i, page := range pages {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		repos = append(repos, page...)
+	}
+	return repos, nil
+}
+
+// uniqueRepositories drops repeated repositories, which page-number pagination can
+// return when repositories are created while the pages are fetched
+func uniqueRepositories(repos []*github.Repository) []*github.Repository {
+	seen := map[int64]bool{}
+	unique := repos[:0]
+	for _, repo := range repos {
+		if !seen[repo.GetID()] {
+			seen[repo.GetID()] = true
+			unique = append(unique, repo)
+		}
+	}
+	return unique
+}
+
+// GetAllRepositories fetches the names of all repositories for an organization
+func (ic *IssueCreator) GetAllRepositories() ([]string, error) {
+	repoList, err := ic.ListRepositories()
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []string
+	for _, repo := range repoList {
+		repos = append(repos, *repo.Name)
+	}
+	return repos, nil
+}
+
+// issueTitle returns the title wrapped in the configured prefix and suffix
+func (ic *IssueCreator) issueTitle() string {
+	return ic.wrapTitle(ic.title)
+}
+
+// wrapTitle adds the configured prefix and suffix to a title
+func (ic *IssueCreator) wrapTitle(title string) string {
+	return joinTitle(ic.titlePrefix, title, ic.titleSuffix)
+}
+
+// joinTitle joins a title with its prefix and suffix, separated by spaces
+func joinTitle(prefix, title, suffix string) string {
+	parts := []string{}
+	for _, part := range []string{prefix, title, suffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// CreateIssue creates an issue in a specific repository
+func (ic *IssueCreator) CreateIssue(repo string) (*github.Issue, error) {
+	title, err := ic.renderTitle(repo)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ic.renderBody(repo)
+	if err != nil {
+		return nil, err
+	}
+	return ic.createIssue(repo, title, body, ic.assignees)
+}
+
+// issueBody returns the description followed by mentions of the team assignees.
+// GitHub cannot assign issues to teams, so teams are notified through a mention instead.
+func (ic *IssueCreator) issueBody() string {
+	return ic.bodyWithExtras(ic.desc)
+}
+
+// bodyWithExtras appends the task checklist, attachments and team mentions to a description
+func (ic *IssueCreator) bodyWithExtras(body string) string {
+	if len(ic.tasks) > 0 {
+		body += "\n\n### Tasks\n\n"
+		for _, task := range ic.tasks {
+			body += fmt.Sprintf("- [ ] %s\n", task)
+		}
+	}
+	if len(ic.attachments) > 0 {
+		body += "\n\n### Attachments\n\n"
+		for _, link := range ic.attachments {
+			body += fmt.Sprintf("- [%s](%s)\n", attachmentName(link), link)
+		}
+	}
+	if len(ic.teamAssignees) == 0 {
+		return body
+	}
+
+	mentions := make([]string, len(ic.teamAssignees))
+	for i, team := range ic.teamAssignees {
+		mentions[i] = fmt.Sprintf("@%s/%s", ic.org, team)
+	}
+	return body + "\n\ncc " + strings.Join(mentions, " ")
+}
+
+// validateAttachments checks that every attachment is an absolute http(s) URL
+func validateAttachments(links []string) error {
+	for _, link := range links {
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid attachment URL %q: expected an absolute http or https URL", link)
+		}
+	}
+	return nil
+}
+
+// attachmentName returns the link text for an attachment, using the last path segment when present
+func attachmentName(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	if name := path.Base(u.Path); name != "." && name != "/" {
+		return name
+	}
+	return link
+}
+
+// CheckTeams verifies that every team assignee exists in the organization
+func (ic *IssueCreator) CheckTeams() error {
+	for _, team := range ic.teamAssignees {
+		if _, _, err := ic.client.Teams.GetTeamBySlug(ic.ctx, ic.org, team); err != nil {
+			return fmt.Errorf("failed to find team %s/%s: %w", ic.org, team, err)
+		}
+	}
+	return nil
+}
+
+// maxTitleLength is the longest issue title GitHub accepts, in characters
+const maxTitleLength = 256
+
+// checkTitleLength rejects titles GitHub would refuse
+func checkTitleLength(title string) error {
+	if n := len([]rune(title)); n > maxTitleLength {
+		return fmt.Errorf("title has %d characters, more than GitHub's limit of %d", n, maxTitleLength)
+	}
+	return nil
+}
+
+// maxBodyLength is the longest issue body GitHub accepts, in characters
+const maxBodyLength = 65536
+
+// truncationNotice is appended to bodies shortened by --truncate-body
+const truncationNotice = "\n\n---\n*This description was truncated to fit GitHub's limit of 65536 characters.*"
+
+// fitBody checks a body against GitHub's length limit. Longer bodies are cut
+// with a notice when truncateBody is set, and rejected otherwise.
+func (ic *IssueCreator) fitBody(body string) (string, error) {
+	runes := []rune(body)
+	if len(runes) <= maxBodyLength {
+		return body, nil
+	}
+	if !ic.truncateBody {
+		return "", fmt.Errorf("body has %d characters, more than GitHub's limit of %d; shorten it or use --truncate-body",
+			len(runes), maxBodyLength)
+	}
+	keep := maxBodyLength - len([]rune(truncationNotice))
+	return string(runes[:keep]) + truncationNotice, nil
+}
+
+// issueRequest builds the request that creates an issue in a repository,
+// resolving the milestone and checking the body length
+func (ic *IssueCreator) issueRequest(repo, title, body string, assignees []string) (*github.IssueRequest, error) {
+	body, err := ic.fitBody(body)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := ic.renderLabels(repo)
+	if err != nil {
+		return nil, err
+	}
+	issueRequest := &github.IssueRequest{
+		Title:  &title,
+		Body:   &body,
+		Labels: &labels,
+	}
+	if len(assignees) > 0 {
+		issueRequest.Assignees = &assignees
+	}
+	if ic.milestone != "" {
+		number, err := ic.milestoneNumber(repo)
+		if err != nil {
+			return nil, err
+		}
+		issueRequest.Milestone = &number
+	}
+	return issueRequest, nil
+}
+
+// createIssue creates an issue with the given title, body and assignees in a specific repository
+func (ic *IssueCreator) createIssue(repo, title, body string, assignees []string) (*github.Issue, error) {
+	issueRequest, err := ic.issueRequest(repo, title, body, assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	ic.pacer.Wait()
+	for attempt := 0; ; attempt++ {
+		ic.limiter.Wait()
+		issue, resp, err := ic.issues.Create(ic.ctx, ic.org, repo, issueRequest)
+		if resp != nil {
+			ic.limiter.Update(resp.Rate)
+		}
+		if err == nil {
+			return issue, nil
+		}
+		if resp != nil && resp.StatusCode == http.StatusGone {
+			return nil, ic.goneError(repo, err)
+		}
+		if url, ok := ssoURL(resp); ok {
+			return nil, &SSOError{Org: ic.org, URL: url}
+		}
+
+		// A 403 that is neither a rate limit nor asks to retry means missing access
+		var errResp *github.ErrorResponse
+		if _, retry := retryAfter(resp); !retry && errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusForbidden {
+			return nil, &PermissionError{Repo: ic.org + "/" + repo}
+		}
+
+		// Secondary rate limits answer with 403 and a Retry-After header
+		wait, ok := retryAfter(resp)
+		if !ok || attempt >= ic.maxRetries {
+			return nil, fmt.Errorf("failed to create issue in %s/%s: %w", ic.org, repo, err)
+		}
+		ic.print(fmt.Sprintf("Secondary rate limit creating issue in %s/%s, retrying in %s\n", ic.org, repo, wait))
+		time.Sleep(wait)
+	}
+}
+
+// milestoneNumber resolves the configured milestone title to its number in a repository
+func (ic *IssueCreator) milestoneNumber(repo string) (int, error) {
+	ic.milestonesMu.Lock()
+	number, ok := ic.milestones[repo]
+	ic.milestonesMu.Unlock()
+	if ok {
+		return number, nil
+	}
+
+	opts := &github.MilestoneListOptions{State: "open", ListOptions: ic.listOptions()}
+	for {
+		milestones, resp, err := ic.issues.ListMilestones(ic.ctx, ic.org, repo, opts)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list milestones in %s/%s: %w", ic.org, repo, err)
+		}
+
+		for _, m := range milestones {
+			if m.GetTitle() == ic.milestone {
+				ic.milestonesMu.Lock()
+				if ic.milestones == nil {
+					ic.milestones = map[string]int{}
+				}
+				ic.milestones[repo] = m.GetNumber()
+				ic.milestonesMu.Unlock()
+				return m.GetNumber(), nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return 0, &MilestoneNotFoundError{Title: ic.milestone, Repo: ic.org + "/" + repo}
+}
+
+// retryAfter reports how long to wait before retrying a request that was
+// rejected with 403 or 429 and a Retry-After header
+func retryAfter(resp *github.Response) (time.Duration, bool) {
+	if resp == nil || resp.Response == nil {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// trackingBody appends a checklist of the given repositories to the description
+// rendered for the tracking repository
+func (ic *IssueCreator) trackingBody(trackingRepo string, repos []string) (string, error) {
+	body, err := ic.renderBody(trackingRepo)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(body)
+	b.WriteString("\n\n")
+	for _, repo := range repos {
+		fmt.Fprintln(&b, checklistLine(ic.org, repo))
+	}
+	return b.String(), nil
+}
+
+// CreateTrackingIssue creates a single issue in trackingRepo whose body lists repos as a checklist
+func (ic *IssueCreator) CreateTrackingIssue(trackingRepo string, repos []string) (*github.Issue, error) {
+	title, err := ic.renderTitle(trackingRepo)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ic.trackingBody(trackingRepo, repos)
+	if err != nil {
+		return nil, err
+	}
+	return ic.createIssue(trackingRepo, title, body, ic.assignees)
+}
+
+// Result statuses reported per repository
+const (
+	StatusCreated = "created"
+	StatusFailed  = "failed"
+	StatusDryRun  = "dry-run"
+	StatusSkipped = "skipped"
+	StatusGone    = "gone"
+
+	// StatusNoPermission marks a repository the token can read but not create issues in
+	StatusNoPermission = "no-permission"
+
+	// StatusCommented marks a repository where an existing issue was commented on
+	StatusCommented = "commented"
+
+	// StatusNotProcessed marks a repository that was never reached because the run was aborted
+	StatusNotProcessed = "not-processed"
+)
+
+// needsRetry reports whether a rerun should target the repository of a result
+func needsRetry(status string) bool {
+	return status == StatusFailed || status == StatusNotProcessed
+}
+
+// RepoResult records the outcome of creating an issue in one repository
+type RepoResult struct {
+	Repo   string `json:"repo"`
+	Status string `json:"status"`
+	Issue  int    `json:"issue,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Reason string `json:"reason,omitempty"`
+
+	// Assignee is the user picked from the assignee rotation
+	Assignee string `json:"assignee,omitempty"`
+
+	// MovedTo is the new owner/name of a repository that was transferred or renamed
+	MovedTo string `json:"moved_to,omitempty"`
+
+	// Warnings lists follow-up steps that failed after the issue was created
+	Warnings []string `json:"warnings,omitempty"`
+
+	// request is the create request a dry run would have sent, kept for --requests-out
+	request *github.IssueRequest
+
+	// authFailed marks a failure caused by the token being rejected
+	authFailed bool
+}
+
+// printResult writes the status mark for a finished repository
+func printResult(w io.Writer, result RepoResult) {
+	assigned := ""
+	if result.Assignee != "" {
+		assigned = " → @" + result.Assignee
+	}
+
+	switch result.Status {
+	case StatusCreated:
+		fmt.Fprintf(w, "%s #%d%s\n", markOK(), result.Issue, assigned)
+	case StatusCommented:
+		fmt.Fprintf(w, "%s commented on #%d\n", markOK(), result.Issue)
+	case StatusDryRun:
+		if result.Issue != 0 {
+			fmt.Fprintf(w, "%s (dry run: would comment on #%d)\n", markOK(), result.Issue)
+			break
+		}
+		fmt.Fprintf(w, "%s (dry run)%s\n", markOK(), assigned)
+	case StatusSkipped:
+		fmt.Fprintf(w, "- (skipped: %s)\n", result.Reason)
+	default:
+		fmt.Fprintf(w, "%s (%s)\n", markFail(), result.Error)
+	}
+}
+
+// PinIssue pins an issue to the top of its repository's issue list
+func (ic *IssueCreator) PinIssue(issue *github.Issue) error {
+	const mutation = `mutation($id: ID!) { pinIssue(input: {issueId: $id}) { issue { id } } }`
+	if err := ic.graphQL(mutation, map[string]interface{}{"id": issue.GetNodeID()}, nil); err != nil {
+		return fmt.Errorf("failed to pin issue #%d: %w", issue.GetNumber(), err)
+	}
+	return nil
+}
+
+// LockIssue locks an issue's conversation with the given reason
+func (ic *IssueCreator) LockIssue(repo string, number int, reason string) error {
+	_, err := ic.issues.Lock(ic.ctx, ic.org, repo, number, &github.LockIssueOptions{LockReason: reason})
+	if err != nil {
+		return fmt.Errorf("failed to lock issue #%d: %w", number, err)
+	}
+	return nil
+}
+
+// runFollowUps performs the configured steps on a newly created issue.
+// Failures are reported and recorded as warnings; the issue itself stays created.
+func (ic *IssueCreator) runFollowUps(w io.Writer, repo string, issue *github.Issue, result *RepoResult) {
+	type step struct {
+		name string
+		run  func() error
+	}
+
+	// Subscribe first: commenting may no longer be possible once the issue is locked
+	if len(ic.subscribers) > 0 {
+		failed, err := ic.SubscribeUsers(w, repo, issue, ic.subscribers)
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "    subscribe... %s (%v; issue remains at %s)\n", markFail(), err, issue.GetHTMLURL())
+			result.Warnings = append(result.Warnings, fmt.Sprintf("subscribe: %v", err))
+		case len(failed) > 0:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("subscribe: unknown users %s", mentionList(failed)))
+		}
+	}
+
+	// Move the issue before the remaining steps act on it
+	if ic.convertToDiscussion != "" {
+		fmt.Fprintf(w, "    convert to discussion... ")
+		url, err := ic.ConvertToDiscussion(repo, issue, ic.convertToDiscussion)
+		switch {
+		case errors.Is(err, errDiscussionsDisabled):
+			fmt.Fprintf(w, "- (skipped: %v; issue remains at %s)\n", err, issue.GetHTMLURL())
+			result.Warnings = append(result.Warnings, fmt.Sprintf("convert to discussion: %v", err))
+		case err != nil:
+			fmt.Fprintf(w, "%s (%v; issue remains at %s)\n", markFail(), err, issue.GetHTMLURL())
+			result.Warnings = append(result.Warnings, fmt.Sprintf("convert to discussion: %v", err))
+		default:
+			fmt.Fprintf(w, "%s %s\n", markOK(), url)
+		}
+	}
+
+	var steps []step
+	if ic.addToProject != "" {
+		steps = append(steps, step{"add to project", func() error { return ic.AddToProject(ic.addToProject, issue) }})
+	}
+	if ic.announce && ic.pin {
+		steps = append(steps, step{"pin", func() error { return ic.PinIssue(issue) }})
+	}
+	if ic.lock {
+		steps = append(steps, step{"lock", func() error { return ic.LockIssue(repo, issue.GetNumber(), ic.lockReason) }})
+	}
+
+	for _, s := range steps {
+		fmt.Fprintf(w, "    %s... ", s.name)
+		if err := s.run(); err != nil {
+			fmt.Fprintf(w, "%s (%v; issue remains at %s)\n", markFail(), err, issue.GetHTMLURL())
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %v", s.name, err))
+			continue
+		}
+		fmt.Fprintln(w, markOK())
+	}
+}
 
-	return &IssueCreator{
-		client: client,
-		ctx:    ctx,
-		org:    org,
-		title:  title,
-		desc:   desc,
-		labels: labels,
-	}, nil
+// fileExists reports whether a file or directory exists on the default branch of a repository
+func (ic *IssueCreator) fileExists(repo, path string) (bool, error) {
+	_, _, resp, err := ic.repos.GetContents(ic.ctx, ic.org, repo, path, nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check %s in %s/%s: %w", path, ic.org, repo, err)
+	}
+	return true, nil
 }
 
-// GetAllRepositories fetches all repositories for an organization
-func (ic *IssueCreator) GetAllRepositories() ([]string, error) {
-	opts := &github.RepositoryListByOrgOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
+// skipReason returns why a repository should be skipped, or an empty string to process it
+func (ic *IssueCreator) skipReason(repo string) (string, error) {
+	if ic.requireWrite {
+		ok, err := ic.hasWriteAccess(repo)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "no permission to create issues", nil
+		}
+	}
+	if ic.dedupLabel != "" {
+		issue, err := ic.findLabeledIssue(repo, ic.dedupLabel)
+		if err != nil {
+			return "", err
+		}
+		if issue != nil {
+			return fmt.Sprintf("#%d already carries %s", issue.GetNumber(), ic.dedupLabel), nil
+		}
+	}
+	if ic.onlyIfMissing != "" {
+		exists, err := ic.fileExists(repo, ic.onlyIfMissing)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return fmt.Sprintf("%s is present", ic.onlyIfMissing), nil
+		}
+	}
+	if ic.onlyIfPresent != "" {
+		exists, err := ic.fileExists(repo, ic.onlyIfPresent)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return fmt.Sprintf("%s is missing", ic.onlyIfPresent), nil
+		}
 	}
+	return "", nil
+}
 
-	var repos []string
-	for {
-		repoList, resp, err := ic.client.Repositories.ListByOrg(ic.ctx, ic.org, opts)
+// ValidateRepository checks that the labels, assignees and milestone of the issue
+// exist in a repository, reporting every problem that would make creation fail
+func (ic *IssueCreator) ValidateRepository(repo string) error {
+	var problems []string
+
+	labels, err := ic.renderLabels(repo)
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if label == ic.dedupLabel || ic.createLabels {
+			// Missing labels are created before the issue
+			continue
+		}
+		_, resp, err := ic.issues.GetLabel(ic.ctx, ic.org, repo, label)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch repositories: %w", err)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				problems = append(problems, fmt.Sprintf("label %q does not exist", label))
+				continue
+			}
+			return fmt.Errorf("failed to check label %q in %s/%s: %w", label, ic.org, repo, err)
 		}
+	}
 
-		for _, repo := range repoList {
-			repos = append(repos, *repo.Name)
+	for _, assignee := range ic.assignees {
+		ok, err := ic.canAssign(repo, assignee)
+		if err != nil {
+			return err
 		}
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%q cannot be assigned", assignee))
+		}
+	}
 
-		if resp.NextPage == 0 {
-			break
+	if ic.milestone != "" {
+		if _, err := ic.milestoneNumber(repo); err != nil {
+			problems = append(problems, err.Error())
 		}
-		opts.Page = resp.NextPage
 	}
 
-	return repos, nil
+	if len(problems) > 0 {
+		return fmt.Errorf("validation failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
 }
 
-// CreateIssue creates an issue in a specific repository
-func (ic *IssueCreator) CreateIssue(repo string) error {
-	issueRequest := &github.IssueRequest{
-		Title:  &ic.title,
-		Body:   &ic.desc,
-		Labels: &ic.labels,
+// simulatedFailure randomly fails the configured percentage of dry-run repositories
+func (ic *IssueCreator) simulatedFailure() error {
+	if ic.simulateFailures > 0 && rand.Float64()*100 < ic.simulateFailures {
+		return fmt.Errorf("simulated failure")
 	}
+	return nil
+}
 
-	_, _, err := ic.client.Issues.Create(ic.ctx, ic.org, repo, issueRequest)
-	if err != nil {
-		return fmt.Errorf("failed to create issue in %s/%s: %w", ic.org, repo, err)
+// processRepo creates the issue in one repository and returns its result
+// together with the progress output for that repository
+func (ic *IssueCreator) processRepo(index int, repo string) (RepoResult, string) {
+	var out strings.Builder
+	fmt.Fprintf(&out, "Creating issue in %s/%s... ", ic.org, repo)
+	result := RepoResult{Repo: repo}
+
+	// Spread the issues over the rotation in repository order
+	assignees := ic.assignees
+	if len(ic.assigneeRotation) > 0 {
+		result.Assignee = ic.assigneeRotation[index%len(ic.assigneeRotation)]
+		assignees = mergeLists(assignees, []string{result.Assignee})
 	}
 
-	return nil
+	reason, err := ic.skipReason(repo)
+	if err == nil && reason != "" {
+		result.Status = StatusSkipped
+		result.Reason = reason
+		printResult(&out, result)
+		return result, out.String()
+	}
+
+	if err == nil && ic.skipDuplicates {
+		var existing *github.Issue
+		existing, err = ic.FindDuplicate(repo)
+		if err == nil && existing != nil {
+			result.Issue = existing.GetNumber()
+			result.URL = existing.GetHTMLURL()
+			switch {
+			case ic.commentOnExisting == "":
+				result.Status = StatusSkipped
+				result.Reason = fmt.Sprintf("duplicate of #%d", existing.GetNumber())
+			case ic.dryRun:
+				result.Status = StatusDryRun
+			default:
+				err = ic.CommentOnIssue(repo, existing.GetNumber(), ic.commentOnExisting)
+				result.Status = StatusCommented
+			}
+			if err == nil {
+				printResult(&out, result)
+				return result, out.String()
+			}
+		}
+	}
+
+	if err == nil && ic.validateAssignees && len(assignees) > 0 {
+		var invalid []string
+		assignees, invalid, err = ic.checkAssignees(repo, assignees)
+		if err == nil && len(invalid) > 0 {
+			if ic.strictAssignees {
+				result.Status = StatusSkipped
+				result.Reason = fmt.Sprintf("cannot assign %s", mentionList(invalid))
+				printResult(&out, result)
+				return result, out.String()
+			}
+			fmt.Fprintf(&out, "(cannot assign %s) ", mentionList(invalid))
+			result.Warnings = append(result.Warnings, fmt.Sprintf("not assigned to %s", mentionList(invalid)))
+		}
+	}
+
+	// Render in dry runs too, so template errors surface before a real run
+	var issue *github.Issue
+	var title, body string
+	target := repo
+	if err == nil {
+		title, err = ic.renderTitle(repo)
+	}
+	if err == nil {
+		body, err = ic.renderBody(repo)
+	}
+	if err == nil {
+		if ic.dryRun {
+			if ic.recordRequests {
+				result.request, err = ic.issueRequest(repo, title, body, assignees)
+			} else {
+				_, err = ic.fitBody(body)
+			}
+			if err == nil {
+				err = ic.simulatedFailure()
+			}
+			if err == nil && ic.validate {
+				err = ic.ValidateRepository(repo)
+			}
+		} else {
+			if ic.createLabels {
+				err = ic.ensureLabels(repo)
+			}
+			if err == nil {
+				issue, err = ic.createIssue(repo, title, body, assignees)
+			}
+
+			// Follow a rename within the organization so the issue still gets created
+			var gone *GoneError
+			if errors.As(err, &gone) {
+				if name, ok := gone.movedWithin(ic.org); ok {
+					fmt.Fprintf(&out, "(moved to %s) ", gone.MovedTo)
+					result.MovedTo = gone.MovedTo
+					target = name
+					issue, err = ic.createIssue(name, title, body, assignees)
+				}
+			}
+		}
+	}
+
+	var gone *GoneError
+	var denied *PermissionError
+	switch {
+	case errors.As(err, &gone):
+		result.Status = StatusGone
+		result.Error = err.Error()
+		result.MovedTo = gone.MovedTo
+	case errors.As(err, &denied):
+		result.Status = StatusNoPermission
+		result.Error = err.Error()
+	case err != nil:
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		result.authFailed = isAuthError(err)
+	case ic.dryRun:
+		result.Status = StatusDryRun
+	default:
+		result.Status = StatusCreated
+		result.Issue = issue.GetNumber()
+		result.URL = issue.GetHTMLURL()
+	}
+	printResult(&out, result)
+	if issue != nil {
+		ic.runFollowUps(&out, target, issue, &result)
+	}
+	return result, out.String()
 }
 
-// CreateIssuesInRepositories creates issues in multiple repositories
-func (ic *IssueCreator) CreateIssuesInRepositories(repos []string) (int, int) {
+// CreateIssuesInRepositories creates issues in multiple repositories and
+// returns the per-repository results along with the success and failure counts.
+// Repositories are processed by up to ic.concurrency workers; results keep the input order.
+func (ic *IssueCreator) CreateIssuesInRepositories(repos []string) ([]RepoResult, int, int) {
+	results := make([]RepoResult, len(repos))
+	processed := make([]bool, len(repos))
 	success := 0
 	failed := 0
+	authFailures := 0
 
-	for _, repo := range repos {
-		fmt.Printf("Creating issue in %s/%s... ", ic.org, repo)
-		if err := ic.CreateIssue(repo); err != nil {
-			fmt.Printf("✗ (%v)\n", err)
+	var mu sync.Mutex
+	tracker := newProgress(len(repos))
+	stop := func(next int) bool {
+		mu.Lock()
+		abort := ic.maxFailures >= 0 && failed > ic.maxFailures
+		authAbort := !ic.continueOnAuthError && authFailures >= authFailureLimit
+		current := failed
+		mu.Unlock()
+		if authAbort {
+			ic.print(fmt.Sprintf("Aborting: authentication failed for %d repositories in a row; the token may be revoked, expired or lack SSO authorization. "+
+				"%d repositories not processed (use --continue-on-auth-error to keep going)\n", authFailureLimit, len(repos)-next))
+			return true
+		}
+		if abort {
+			ic.print(fmt.Sprintf("Aborting: %d failures exceed --max-failures %d; %d repositories not processed\n",
+				current, ic.maxFailures, len(repos)-next))
+			return true
+		}
+		return false
+	}
+	runPool(len(repos), ic.concurrency, stop, func(i int) {
+		result, output := ic.processRepo(i, repos[i])
+
+		mu.Lock()
+		results[i] = result
+		processed[i] = true
+		switch result.Status {
+		case StatusFailed, StatusGone, StatusNoPermission:
 			failed++
-		} else {
-			fmt.Println("✓")
+		case StatusCreated, StatusDryRun, StatusCommented:
 			success++
 		}
+		if result.authFailed {
+			authFailures++
+		} else {
+			authFailures = 0
+		}
+		prefix := tracker.advance()
+		mu.Unlock()
+
+		ic.print(prefix + output)
+	})
+
+	// Repositories left out by an abort keep a result, so reports and reruns include them
+	for i := range results {
+		if !processed[i] {
+			results[i] = RepoResult{Repo: repos[i], Status: StatusNotProcessed, Reason: "run aborted before this repository"}
+		}
+	}
+	return results, success, failed
+}
+
+// print writes progress output without interleaving it with other workers
+func (ic *IssueCreator) print(output string) {
+	ic.outputMu.Lock()
+	defer ic.outputMu.Unlock()
+	fmt.Fprint(ic.out, output)
+}
+
+// splitList splits a comma-separated value and trims whitespace from each entry
+func splitList(value string) []string {
+	list := []string{}
+	if value == "" {
+		return list
 	}
+	for _, item := range strings.Split(value, ",") {
+		list = append(list, strings.TrimSpace(item))
+	}
+	return list
+}
+
+// listValues returns the values of a repeatable flag as given. A single string from an
+// environment variable or the config file is one value; GetStringSlice would split it on
+// whitespace.
+func listValues(key string) []string {
+	switch value := viper.Get(key).(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{value}
+	default:
+		return viper.GetStringSlice(key)
+	}
+}
 
-	return success, failed
+// listFlag returns the values of a repeatable flag, splitting each value on commas
+// and dropping empty and duplicate entries
+func listFlag(key string) []string {
+	var values []string
+	for _, value := range listValues(key) {
+		values = append(values, splitList(value)...)
+	}
+	return mergeLists(nil, values)
 }
 
 var rootCmd = &cobra.Command{
@@ -111,6 +1233,57 @@ var rootCmd = &cobra.Command{
 	Short: "Create GitHub issues across multiple repositories",
 	Long: `gitissuehelper is a CLI tool to create issues across multiple repositories in a GitHub organization.
 It supports batch issue creation with customizable titles, descriptions, and labels.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Bind the flags of the command being run so that commands can share flag names
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			return err
+		}
+		if err := loadConfig(); err != nil {
+			return err
+		}
+		// validate lists config problems together with its other findings
+		if cmd != validateCmd {
+			if err := validateConfig(cmd.Root(), viper.ConfigFileUsed()); err != nil {
+				return err
+			}
+		}
+		setupColor()
+		return nil
+	},
+}
+
+// loadConfig reads the config file and applies the selected profile.
+// Values are resolved with the following precedence, highest first:
+// command-line flags, GITISSUEHELPER_* environment variables, the selected
+// profile block, top-level config file keys, flag defaults.
+func loadConfig() error {
+	if cfgFile := viper.GetString("config"); cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+		if err := viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file %s: %w", cfgFile, err)
+		}
+	} else {
+		viper.SetConfigName(".gitissuehelper")
+		viper.AddConfigPath(".")
+		if home, err := os.UserHomeDir(); err == nil {
+			viper.AddConfigPath(home)
+		}
+		if err := viper.ReadInConfig(); err != nil {
+			var notFound viper.ConfigFileNotFoundError
+			if !errors.As(err, &notFound) {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+		}
+	}
+
+	profile := viper.GetString("profile")
+	if profile == "" {
+		return nil
+	}
+	if !viper.IsSet("profiles." + profile) {
+		return fmt.Errorf("profile %q not found in config file", profile)
+	}
+	return viper.MergeConfigMap(viper.GetStringMap("profiles." + profile))
 }
 
 var createCmd = &cobra.Command{
@@ -121,98 +1294,617 @@ var createCmd = &cobra.Command{
 
 func runCreate(cmd *cobra.Command, args []string) error {
 	// Get configuration from flags and environment
-	org := viper.GetString("org")
-	title := viper.GetString("title")
-	desc := viper.GetString("description")
-	repos := viper.GetString("repos")
-	labels := viper.GetString("labels")
-	token := viper.GetString("token")
-
-	// Validate required flags
-	if org == "" || title == "" || desc == "" {
-		return fmt.Errorf("missing required arguments: --org, --title, and --description are required")
+	org, err := resolveOrg()
+	if err != nil {
+		return err
 	}
-
-	// Get GitHub token
-	if token == "" {
-		token = os.Getenv("GITHUB_TOKEN")
+	settings, problems := checkCreateSettings(org)
+	if len(problems) > 0 {
+		return problems[0]
 	}
+	org, title, desc, manifest := settings.org, settings.title, settings.desc, settings.manifest
+	labelList, assignees, milestone := settings.labels, settings.assignees, settings.milestone
+	enterprise := viper.GetString("repos-all-orgs")
 
 	// Create IssueCreator
-	labelList := []string{}
-	if labels != "" {
-		labelList = strings.Split(labels, ",")
-		// Trim whitespace from labels
-		for i := range labelList {
-			labelList[i] = strings.TrimSpace(labelList[i])
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, title, desc, labelList, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+	creator.assignees = assignees
+	creator.milestone = milestone
+	creator.assigneeRotation = splitList(viper.GetString("assignee-rotation"))
+	creator.strictAssignees = viper.GetBool("strict-assignees")
+	creator.validateAssignees = viper.GetBool("assignee-validate") || creator.strictAssignees
+	if runURL := viper.GetString("workflow-run-url"); runURL != "" {
+		summary, err := creator.WorkflowFailureSummary(runURL)
+		if err != nil {
+			fmt.Fprintf(creator.out, "Warning: %v; creating issues without a failure summary\n", err)
+		} else {
+			creator.desc += "\n\n" + summary
+		}
+	}
+	if viper.GetBool("template") {
+		location, err := time.LoadLocation(viper.GetString("timezone"))
+		if err != nil {
+			return fmt.Errorf("invalid --timezone: %w", err)
+		}
+		creator.runStart = time.Now().In(location)
+		creator.dateFormat = viper.GetString("date-format")
+		creator.titleTemplate, err = parseTemplate("title", creator.title, creator.runStart)
+		if err != nil {
+			return err
+		}
+		creator.bodyTemplate, err = parseTemplate("description", creator.desc, creator.runStart)
+		if err != nil {
+			return err
+		}
+		creator.fetchRepoInfo = usesRepoInfo(append([]string{creator.title, creator.desc}, creator.labels...))
+		creator.labels, creator.labelTemplates, err = splitLabelTemplates(creator.labels, creator.runStart)
+		if err != nil {
+			return err
+		}
+	}
+	creator.titlePrefix = strings.TrimSpace(viper.GetString("title-prefix"))
+	creator.titleSuffix = strings.TrimSpace(viper.GetString("title-suffix"))
+	creator.maxRetries = viper.GetInt("max-retries")
+	creator.concurrency = viper.GetInt("concurrency")
+	creator.pacer.delay = viper.GetDuration("delay-between")
+	creator.maxFailures = viper.GetInt("max-failures")
+	creator.continueOnAuthError = viper.GetBool("continue-on-auth-error")
+	creator.dryRun = viper.GetBool("dry-run")
+	creator.simulateFailures = viper.GetFloat64("simulate")
+	creator.recordRequests = viper.GetString("requests-out") != ""
+	creator.validate = viper.GetBool("validate")
+	creator.truncateBody = viper.GetBool("truncate-body")
+	creator.requireWrite = viper.GetBool("require-write")
+	creator.onlyIfMissing = viper.GetString("only-if-missing")
+	creator.onlyIfPresent = viper.GetString("only-if-present")
+	creator.skipDuplicates = viper.GetBool("skip-duplicates")
+	creator.commentOnExisting = viper.GetString("comment-on-existing")
+	creator.announce = viper.GetBool("announce")
+	creator.pin = viper.GetBool("pin")
+	creator.lock = creator.announce || viper.GetBool("create-locked")
+	creator.lockReason = viper.GetString("lock-reason")
+	if creator.announce && creator.lockReason == "" {
+		creator.lockReason = "off-topic"
+	}
+	creator.addToProject = viper.GetString("add-to-project")
+	creator.convertToDiscussion = viper.GetString("convert-to-discussion")
+	creator.subscribers = listFlag("subscribe")
+
+	if tasksFile := viper.GetString("tasks-file"); tasksFile != "" {
+		// Tasks use the line format of --repos-file
+		creator.tasks, err = readReposFile(tasksFile)
+		if err != nil {
+			return err
+		}
+	}
+	creator.attachments = splitList(viper.GetString("attachments"))
+	creator.teamAssignees = splitList(viper.GetString("team-assignees"))
+	if viper.GetBool("dedup-label") {
+		creator.dedupLabel = dedupLabel(creator.issueTitle(), creator.issueBody())
+		creator.labels = mergeLists(creator.labels, []string{creator.dedupLabel})
+	}
+	if viper.GetBool("create-labels") {
+		if err := creator.setupCreateLabels(); err != nil {
+			return err
 		}
 	}
 
-	creator, err := NewIssueCreator(token, org, title, desc, labelList)
+	// Catch tokens without the needed scopes before any request fails cryptically.
+	// A dry run only reads, so missing scopes are reported as a warning there.
+	warnings, err := creator.CheckScopes()
 	if err != nil {
-		return fmt.Errorf("failed to initialize: %v", err)
+		var scopeErr *ScopeError
+		if !creator.dryRun || !errors.As(err, &scopeErr) {
+			return err
+		}
+		warnings = append(warnings, err.Error())
+	}
+	if org != "" {
+		orgWarnings, err := creator.CheckOrgAccess()
+		if err != nil {
+			return err
+		}
+		warnings = append(warnings, orgWarnings...)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if len(creator.teamAssignees) > 0 {
+		if err := creator.CheckTeams(); err != nil {
+			return err
+		}
 	}
 
 	// Get repositories
 	var repoList []string
-	if repos != "" {
-		// Use provided repositories
-		repoList = strings.Split(repos, ",")
-		// Trim whitespace
-		for i := range repoList {
-			repoList[i] = strings.TrimSpace(repoList[i])
-		}
+	if manifest != nil {
+		repoList = manifest.Repos
 	} else {
-		// Fetch all repositories
-		fmt.Printf("Fetching repositories from organization: %s...\n", org)
-		repoList, err = creator.GetAllRepositories()
+		repoList, err = resolveRepos(creator)
+		if err != nil {
+			return err
+		}
+	}
+
+	// A typo in the repository filters should not become an organization-wide campaign.
+	// A --tracking-repo run creates a single issue however many repositories it lists.
+	if err := checkMaxRepos(len(repoList)); err != nil && viper.GetString("tracking-repo") == "" {
+		if !creator.dryRun {
+			return fmt.Errorf("refusing to create issues: %w", err)
+		}
+		fmt.Fprintf(creator.out, "Warning: %v before the real run\n", err)
+	}
+	if org != "" {
+		// owner/name entries override --org for those repositories
+		var mixed bool
+		repoList, mixed, err = qualifyRepos(org, repoList)
 		if err != nil {
-			return fmt.Errorf("failed to fetch repositories: %v", err)
+			return err
+		}
+		if mixed {
+			if name := singleOwnerFlag(); name != "" {
+				return fmt.Errorf("--%s cannot be used when the repositories belong to several owners", name)
+			}
+			return creator.createAcrossOwners(repoList)
 		}
 	}
 
 	if len(repoList) == 0 {
 		return fmt.Errorf("no repositories found")
 	}
+	if org == "" {
+		if enterprise != "" && !creator.dryRun {
+			owners, _, err := groupByOwner(repoList)
+			if err != nil {
+				return err
+			}
+			if err := confirmEnterprise(enterprise, len(owners), len(repoList)); err != nil {
+				return err
+			}
+		}
+		return creator.createAcrossOwners(repoList)
+	}
+	if err := creator.checkTitles(repoList); err != nil {
+		return err
+	}
+
+	// Label templates are kept unrendered in the manifest, like the title and body
+	if manifestPath := viper.GetString("write-manifest"); manifestPath != "" {
+		err := writeManifest(manifestPath, Manifest{
+			Org:       org,
+			Repos:     repoList,
+			Title:     creator.issueTitle(),
+			Body:      creator.issueBody(),
+			Labels:    mergeLists(labelList, creator.labels),
+			Assignees: creator.assignees,
+			Milestone: creator.milestone,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(creator.out, "Manifest written to %s\n", manifestPath)
+	}
+
+	// Create a single tracking issue instead of one issue per repository
+	if trackingRepo := viper.GetString("tracking-repo"); trackingRepo != "" {
+		if number := viper.GetInt("tracking-issue"); number > 0 {
+			return creator.appendTracking(trackingRepo, number, repoList)
+		}
+		if creator.dryRun {
+			body, err := creator.trackingBody(trackingRepo, repoList)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(creator.out, "Would create tracking issue in %s/%s:\n%s", org, trackingRepo, body)
+			return nil
+		}
+		fmt.Fprintf(creator.out, "Creating tracking issue in %s/%s for %d repositories... ", org, trackingRepo, len(repoList))
+		issue, err := creator.CreateTrackingIssue(trackingRepo, repoList)
+		if err != nil {
+			fmt.Fprintln(creator.out, markFail())
+			return err
+		}
+		fmt.Fprintf(creator.out, "%s %s\n", markOK(), issue.GetHTMLURL())
+		return nil
+	}
 
 	// Create issues
-	fmt.Printf("Creating issues in organization: %s\n", org)
-	fmt.Printf("Title: %s\n", title)
-	fmt.Printf("Repositories: %d\n", len(repoList))
-	fmt.Println("---")
+	fmt.Fprintf(creator.out, "Creating issues in organization: %s\n", org)
+	fmt.Fprintf(creator.out, "Title: %s\n", creator.issueTitle())
+	fmt.Fprintf(creator.out, "Repositories: %d\n", len(repoList))
+	if len(creator.teamAssignees) > 0 {
+		fmt.Fprintf(creator.out, "Team assignees: mentioned in the body (GitHub cannot assign issues to teams): %s\n",
+			strings.Join(creator.teamAssignees, ", "))
+	}
+	if delay := creator.pacer.delay; delay > 0 && !creator.dryRun {
+		fmt.Fprintf(creator.out, "Delay between creations: %s (at most %.1f issues per minute)\n", delay, creator.pacer.perMinute())
+	}
+	fmt.Fprintln(creator.out, "---")
 
-	success, failed := creator.CreateIssuesInRepositories(repoList)
+	started := time.Now()
+	results, success, failed := creator.CreateIssuesInRepositories(repoList)
+	creator.printSummary(results, success, failed)
+	if creator.pacer.delay > 0 && !creator.dryRun {
+		creator.printRate(results, time.Since(started))
+	}
 
-	fmt.Println("---")
-	fmt.Printf("Summary: %d succeeded, %d failed\n", success, failed)
+	if requestsOut := viper.GetString("requests-out"); requestsOut != "" {
+		if err := writeRequests(requestsOut, org, results); err != nil {
+			return err
+		}
+		fmt.Fprintf(creator.out, "Requests written to %s\n", requestsOut)
+	}
+	if reportPath := viper.GetString("report-markdown"); reportPath != "" {
+		if err := writeMarkdownReport(reportPath, org, results); err != nil {
+			return err
+		}
+		fmt.Fprintf(creator.out, "Report written to %s\n", reportPath)
+	}
+	if reportPath := viper.GetString("report-csv"); reportPath != "" {
+		if err := writeCSVReport(reportPath, results); err != nil {
+			return err
+		}
+		fmt.Fprintf(creator.out, "Report written to %s\n", reportPath)
+	}
+	if failuresPath := viper.GetString("failures-file"); failuresPath != "" {
+		count, err := writeFailures(failuresPath, results)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(creator.out, "%d failed or unprocessed repositories written to %s\n", count, failuresPath)
+	}
+	if resultsPath := viper.GetString("results-out"); resultsPath != "" {
+		if err := writeResults(resultsPath, results); err != nil {
+			return err
+		}
+		fmt.Fprintf(creator.out, "Results written to %s\n", resultsPath)
+	}
+	if numbersPath := viper.GetString("numbers-out"); numbersPath != "" {
+		count, err := writeNumbers(numbersPath, results)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(creator.out, "Issue numbers of %d repositories written to %s\n", count, numbersPath)
+	}
 
 	if failed > 0 {
+		if !viper.GetBool("quiet") {
+			creator.printNextSteps(results)
+		}
 		os.Exit(1)
 	}
 
 	return nil
 }
 
+// appendTracking adds the missing repositories to an existing tracking issue's checklist
+func (ic *IssueCreator) appendTracking(trackingRepo string, number int, repos []string) error {
+	if ic.dryRun {
+		fmt.Fprintf(ic.out, "Would update tracking issue %s/%s#%d... ", ic.org, trackingRepo, number)
+	} else {
+		fmt.Fprintf(ic.out, "Updating tracking issue %s/%s#%d... ", ic.org, trackingRepo, number)
+	}
+	issue, added, overflow, err := ic.AppendToTrackingIssue(trackingRepo, number, repos)
+	if err != nil {
+		fmt.Fprintln(ic.out, markFail())
+		return err
+	}
+	fmt.Fprintf(ic.out, "%s %s\n", markOK(), issue.GetHTMLURL())
+
+	for _, repo := range added {
+		fmt.Fprintf(ic.out, "  + %s/%s\n", ic.org, repo)
+	}
+	fmt.Fprintln(ic.out, "---")
+	fmt.Fprintf(ic.out, "Summary: %d added, %d already listed", len(added), len(repos)-len(added)-len(overflow))
+	if len(overflow) == 0 {
+		fmt.Fprintln(ic.out)
+		return nil
+	}
+	fmt.Fprintf(ic.out, ", %d not added\n", len(overflow))
+	fmt.Fprintf(ic.out, "The tracking issue body would exceed %d characters; list these repositories in a new tracking issue:\n", maxBodyLength)
+	for _, repo := range overflow {
+		fmt.Fprintf(ic.out, "  %s/%s\n", ic.org, repo)
+	}
+	os.Exit(1)
+	return nil
+}
+
+// singleOwnerFlags are the create flags that only work with repositories of a single owner
+var singleOwnerFlags = []string{"team-assignees", "tracking-repo", "write-manifest", "requests-out", "report-markdown", "report-csv", "failures-file", "numbers-out", "results-out"}
+
+// singleOwnerFlag returns the first single-owner flag that is set, or "" when there is none
+func singleOwnerFlag() string {
+	for _, name := range singleOwnerFlags {
+		if viper.GetString(name) != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// defaultDescriptionURLTimeout bounds fetching --description-url unless configured otherwise
+const defaultDescriptionURLTimeout = 10 * time.Second
+
+// defaultMaxRepos is how many repositories create targets at most without --confirm-large
+const defaultMaxRepos = 50
+
+// checkMaxRepos rejects runs targeting more repositories than --max-repos allows
+func checkMaxRepos(count int) error {
+	maxRepos := viper.GetInt("max-repos")
+	if maxRepos <= 0 || count <= maxRepos || viper.GetBool("confirm-large") {
+		return nil
+	}
+	return fmt.Errorf("%d repositories are targeted, more than --max-repos %d; check the repository filters, "+
+		"then raise --max-repos or pass --confirm-large", count, maxRepos)
+}
+
+// createAcrossOwners creates the issues for owner/name references of several owners,
+// one owner after another with a summary each
+func (ic *IssueCreator) createAcrossOwners(refs []string) error {
+	owners, byOwner, err := groupByOwner(refs)
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		ic.org = owner
+		if err := ic.checkTitles(byOwner[owner]); err != nil {
+			return err
+		}
+	}
+
+	totalFailed := 0
+	for _, owner := range owners {
+		// Cached milestones and assignees are keyed by repository name within one owner
+		ic.org = owner
+		ic.milestones = nil
+		ic.assignable = nil
+
+		fmt.Fprintf(ic.out, "Creating issues in organization: %s\n", owner)
+		fmt.Fprintf(ic.out, "Title: %s\n", ic.issueTitle())
+		fmt.Fprintf(ic.out, "Repositories: %d\n", len(byOwner[owner]))
+		fmt.Fprintln(ic.out, "---")
+
+		started := time.Now()
+		results, success, failed := ic.CreateIssuesInRepositories(byOwner[owner])
+		ic.printSummary(results, success, failed)
+		if ic.pacer.delay > 0 && !ic.dryRun {
+			ic.printRate(results, time.Since(started))
+		}
+		fmt.Fprintln(ic.out)
+		totalFailed += failed
+	}
+
+	if totalFailed > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// printSummary prints the counts and any follow-up warnings of a creation run
+func (ic *IssueCreator) printSummary(results []RepoResult, success, failed int) {
+	org := ic.org
+	skipped := 0
+	denied := 0
+	notProcessed := 0
+	var gone, moved, movedFailed []RepoResult
+	for _, result := range results {
+		switch {
+		case result.Status == StatusSkipped:
+			skipped++
+		case result.Status == StatusNotProcessed:
+			notProcessed++
+		case result.Status == StatusNoPermission:
+			denied++
+		case result.Status == StatusGone:
+			gone = append(gone, result)
+		case result.MovedTo != "" && result.Status == StatusCreated:
+			moved = append(moved, result)
+		case result.MovedTo != "" && result.Status == StatusFailed:
+			movedFailed = append(movedFailed, result)
+		}
+	}
+
+	fmt.Fprintln(ic.out, "---")
+	counts := fmt.Sprintf("Summary: %d succeeded, %d failed", success, failed-len(gone)-denied)
+	if denied > 0 {
+		counts += fmt.Sprintf(", %d no permission to create", denied)
+	}
+	if len(gone) > 0 {
+		counts += fmt.Sprintf(", %d moved or gone", len(gone))
+	}
+	if skipped > 0 {
+		counts += fmt.Sprintf(", %d skipped", skipped)
+	}
+	if notProcessed > 0 {
+		counts += fmt.Sprintf(", %d not processed", notProcessed)
+	}
+	fmt.Fprintln(ic.out, counts)
+
+	// Moved and gone repositories mean the repository list needs updating
+	if len(gone)+len(moved)+len(movedFailed) > 0 {
+		fmt.Fprintln(ic.out, "Update the repository list:")
+		for _, result := range moved {
+			fmt.Fprintf(ic.out, "  %s/%s → %s (issue created at the new location)\n", org, result.Repo, result.MovedTo)
+		}
+		for _, result := range movedFailed {
+			fmt.Fprintf(ic.out, "  %s/%s → %s (failed at the new location: %s)\n", org, result.Repo, result.MovedTo, result.Error)
+		}
+		for _, result := range gone {
+			fmt.Fprintf(ic.out, "  %s/%s: %s\n", org, result.Repo, result.Error)
+		}
+	}
+	for _, result := range results {
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(ic.out, "Warning: %s/%s#%d %s\n", org, result.Repo, result.Issue, warning)
+		}
+	}
+
+	// Report the rotation as assignee: repositories
+	var rotation []string
+	assigned := map[string][]string{}
+	for _, result := range results {
+		if result.Assignee == "" || (result.Status != StatusCreated && result.Status != StatusDryRun) {
+			continue
+		}
+		if assigned[result.Assignee] == nil {
+			rotation = append(rotation, result.Assignee)
+		}
+		assigned[result.Assignee] = append(assigned[result.Assignee], result.Repo)
+	}
+	if len(rotation) > 0 {
+		fmt.Fprintln(ic.out, "Assignee rotation:")
+		for _, user := range rotation {
+			fmt.Fprintf(ic.out, "  @%s: %s\n", user, strings.Join(assigned[user], ", "))
+		}
+	}
+}
+
+// printNextSteps tells the operator how to follow up on a run with failures
+func (ic *IssueCreator) printNextSteps(results []RepoResult) {
+	retry, gone, denied := 0, 0, 0
+	for _, result := range results {
+		switch result.Status {
+		case StatusFailed, StatusNotProcessed:
+			retry++
+		case StatusGone:
+			gone++
+		case StatusNoPermission:
+			denied++
+		}
+	}
+
+	fmt.Fprintln(ic.out, "Next steps:")
+	if retry > 0 {
+		switch {
+		case viper.GetString("failures-file") != "":
+			fmt.Fprintf(ic.out, "  Retry the %d failed or unprocessed repositories: rerun the same command with --repos-file %s\n", retry, viper.GetString("failures-file"))
+		case viper.GetString("report-csv") != "":
+			fmt.Fprintf(ic.out, "  Retry the %d failed or unprocessed repositories: rerun the same command with --rerun-failed %s\n", retry, viper.GetString("report-csv"))
+		default:
+			fmt.Fprintf(ic.out, "  Retry the %d failed or unprocessed repositories: add --failures-file failed.txt, then rerun with --repos-file failed.txt\n", retry)
+		}
+	}
+	if gone > 0 {
+		fmt.Fprintf(ic.out, "  Remove or update the %d moved or gone repositories in the repository list\n", gone)
+	}
+	if denied > 0 {
+		fmt.Fprintf(ic.out, "  Check the token's access to the %d repositories without permission to create issues\n", denied)
+	}
+	fmt.Fprintln(ic.out, "  Exit code 1 means at least one repository failed; 0 means every repository succeeded or was skipped")
+}
+
+// printRate reports the creation rate actually achieved under --delay-between
+func (ic *IssueCreator) printRate(results []RepoResult, elapsed time.Duration) {
+	created := 0
+	for _, result := range results {
+		if result.Status == StatusCreated {
+			created++
+		}
+	}
+	if created == 0 || elapsed <= 0 {
+		return
+	}
+	fmt.Fprintf(ic.out, "Effective rate: %.1f issues per minute (%d created in %s)\n",
+		float64(created)/elapsed.Minutes(), created, elapsed.Round(time.Second))
+}
+
 func init() {
 	// Bind environment variables
+	// GITISSUEHELPER_<FLAG> maps to --<flag>, with dashes written as underscores
 	viper.SetEnvPrefix("GITISSUEHELPER")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
+	// Global flags
+	rootCmd.PersistentFlags().String("config", "", "Config file (default is .gitissuehelper.yaml in the current or home directory)")
+	rootCmd.PersistentFlags().String("profile", "", "Named block under \"profiles\" in the config file to apply (optional)")
+	rootCmd.PersistentFlags().String("token", "", "GitHub API token (optional; uses GITHUB_TOKEN env var if not provided)")
+	rootCmd.PersistentFlags().String("oidc-exchange-url", "", "In GitHub Actions, exchange the job's OIDC token for a GitHub token at this URL (optional)")
+	rootCmd.PersistentFlags().String("oidc-audience", "", "Audience requested for the Actions OIDC token (optional)")
+	rootCmd.PersistentFlags().Int("per-page", maxPerPage, "Page size for paginated API calls (1-100)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output; also disabled by NO_COLOR and when stdout is not a terminal")
+	rootCmd.PersistentFlags().Bool("trace", false, "Log every HTTP request and response to stderr, with the Authorization header redacted")
+	rootCmd.PersistentFlags().String("ca-cert", "", "PEM file with additional root CA certificates to trust (optional)")
+	rootCmd.PersistentFlags().String("user-agent", "", "User-Agent header sent with every request (default gitissuehelper/<version>)")
+	rootCmd.PersistentFlags().Duration("dial-timeout", 30*time.Second, "Timeout for establishing a connection to the API")
+	rootCmd.PersistentFlags().Duration("tls-handshake-timeout", 10*time.Second, "Timeout for the TLS handshake with the API")
+	rootCmd.PersistentFlags().Duration("response-header-timeout", 60*time.Second, "Timeout for the API to start answering a request (0 waits forever)")
+	rootCmd.PersistentFlags().Duration("keep-alive", 30*time.Second, "Interval of TCP keep-alive probes on API connections (negative disables them)")
+
 	// Create command flags
-	createCmd.Flags().StringP("org", "o", "", "GitHub organization name (required)")
+	addRepoFlags(createCmd)
 	createCmd.Flags().StringP("title", "t", "", "Issue title (required)")
 	createCmd.Flags().StringP("description", "d", "", "Issue description (required)")
-	createCmd.Flags().StringP("repos", "r", "", "Comma-separated list of repository names (optional; if omitted, all repos in org are used)")
-	createCmd.Flags().StringP("labels", "l", "", "Comma-separated labels to add to issues (optional)")
-	createCmd.Flags().String("token", "", "GitHub API token (optional; uses GITHUB_TOKEN env var if not provided)")
-
-	// Bind flags to Viper
-	viper.BindPFlag("org", createCmd.Flags().Lookup("org"))
-	viper.BindPFlag("title", createCmd.Flags().Lookup("title"))
-	viper.BindPFlag("description", createCmd.Flags().Lookup("description"))
-	viper.BindPFlag("repos", createCmd.Flags().Lookup("repos"))
-	viper.BindPFlag("labels", createCmd.Flags().Lookup("labels"))
-	viper.BindPFlag("token", createCmd.Flags().Lookup("token"))
+	createCmd.Flags().String("description-base64", "", "Issue description encoded as base64, e.g. from \"base64 -w0 body.md\" (optional)")
+	createCmd.Flags().String("description-file", "", "Read the issue description from a file with optional YAML front-matter (optional)")
+	createCmd.Flags().Bool("title-from-first-line", false, "Use the first non-empty line of the description file as the title, without a leading \"# \" (optional)")
+	createCmd.Flags().String("description-url", "", "Fetch the issue description over HTTP(S), with optional YAML front-matter (optional)")
+	createCmd.Flags().Duration("description-url-timeout", defaultDescriptionURLTimeout, "Timeout for fetching --description-url")
+	createCmd.Flags().String("default-body", "", "Body used when no description is given, e.g. set once in the config file (optional)")
+	createCmd.Flags().String("workflow-run-url", "", "Append a summary of the failed jobs of this GitHub Actions run to the description (optional)")
+	createCmd.Flags().Bool("template", false, "Render the title, description and --labels entries containing {{ as Go templates per repository (optional)")
+	createCmd.Flags().String("date-format", time.RFC3339, "Go time layout for .Date in templates")
+	createCmd.Flags().String("timezone", "UTC", "Time zone for .Date and now in templates, e.g. Europe/Berlin")
+	createCmd.Flags().Bool("truncate-body", false, "Truncate bodies over GitHub's 65536 character limit with a notice instead of failing (optional)")
+	createCmd.Flags().String("title-prefix", "", "Text prepended to every issue title, e.g. \"[Q3]\" (optional)")
+	createCmd.Flags().String("title-suffix", "", "Text appended to every issue title (optional)")
+	createCmd.Flags().StringArrayP("labels", "l", nil, "Labels to add to issues; repeatable and comma-separated (optional)")
+	createCmd.Flags().Bool("create-labels", false, "Create labels missing in a repository before the issue, using --label-colors and --label-descriptions (optional)")
+	createCmd.Flags().StringArray("label-colors", nil, "Color for a label created by --create-labels as name=color, e.g. bug=d73a4a; repeatable (optional)")
+	createCmd.Flags().StringArray("label-descriptions", nil, "Description for a label created by --create-labels as name=description; repeatable (optional)")
+	createCmd.Flags().StringArray("assignees", nil, "Users to assign to issues; repeatable and comma-separated (optional)")
+	createCmd.Flags().String("milestone", "", "Title of the open milestone to set on issues, resolved per repository (optional)")
+	createCmd.Flags().Bool("assignee-validate", false, "Check in each repository that the assignees can be assigned and leave out those that cannot (optional)")
+	createCmd.Flags().Bool("strict-assignees", false, "Skip repositories where an assignee cannot be assigned; implies --assignee-validate (optional)")
+	createCmd.Flags().String("assignee-rotation", "", "Comma-separated users; each issue is assigned to the next one in turn (optional)")
+	createCmd.Flags().String("tasks-file", "", "File with one task per line, appended to every body as a markdown checklist (optional)")
+	createCmd.Flags().String("attachments", "", "Comma-separated URLs listed in an Attachments section of each body (optional)")
+	createCmd.Flags().String("team-assignees", "", "Comma-separated team slugs to notify; mentioned in the body since GitHub cannot assign issues to teams (optional)")
+	createCmd.Flags().String("repos-all-orgs", "", "Target every repository of every organization in this enterprise, after typing its slug to confirm (optional)")
+	createCmd.Flags().Int("max-repos", defaultMaxRepos, "Refuse to create issues in more repositories than this unless --confirm-large is given (0 disables the cap)")
+	createCmd.Flags().Bool("confirm-large", false, "Create issues even when more repositories than --max-repos are targeted")
+	createCmd.Flags().String("confirm-all-orgs", "", "Enterprise slug that confirms --repos-all-orgs without a prompt, e.g. in CI (optional)")
+	createCmd.Flags().String("tracking-repo", "", "Create one issue in this repository with a checklist of the target repositories instead of one issue per repository (optional)")
+	createCmd.Flags().Int("tracking-issue", 0, "With --tracking-repo, add the repositories missing from the checklist of this existing issue instead of creating one (optional)")
+	createCmd.Flags().String("only-if-missing", "", "Only create the issue in repositories where this path does not exist (optional)")
+	createCmd.Flags().String("only-if-present", "", "Only create the issue in repositories where this path exists (optional)")
+	createCmd.Flags().Bool("require-write", false, "Skip repositories where the token has less than triage access (optional)")
+	createCmd.Flags().Bool("skip-duplicates", false, "Skip repositories that already have an open issue with the same title (optional)")
+	createCmd.Flags().Bool("dedup-label", false, "Add a dedup:<hash> label computed from the title and body and skip repositories with an open issue carrying it (optional)")
+	createCmd.Flags().String("comment-on-existing", "", "With --skip-duplicates, post this comment on the existing issue instead of skipping (optional)")
+	createCmd.Flags().String("write-manifest", "", "Write the resolved organization, repositories and issue content to this YAML file before creating (optional)")
+	createCmd.Flags().String("from-manifest", "", "Reproduce a run from a file written by --write-manifest (optional)")
+	createCmd.Flags().String("report-csv", "", "Write the results as CSV to this file; rerun the failed ones with --rerun-failed (optional)")
+	createCmd.Flags().String("failures-file", "", "Write the names of the failed repositories to this file, one per line, for --repos-file (optional)")
+	createCmd.Flags().String("results-out", "", "Write the result of each repository as JSON, readable by --repos-from-json (optional)")
+	createCmd.Flags().String("numbers-out", "", "Write the issue number of each repository as a repo: number mapping, YAML or .json (optional)")
+	createCmd.Flags().Bool("quiet", false, "Do not print the next steps after a run with failures (optional)")
+	createCmd.Flags().String("report-markdown", "", "Write the results as a markdown table with issue links to this file (optional)")
+	createCmd.Flags().Bool("dry-run", false, "Show what would be created without calling the API (optional)")
+	createCmd.Flags().String("requests-out", "", "During --dry-run, write the create request for each repository as JSON to this file (optional)")
+	createCmd.Flags().Bool("validate", false, "During --dry-run, check that labels, assignees and milestone exist in each repository (optional)")
+	createCmd.Flags().Float64("simulate", 0, "Percentage of repositories to mark as failed during --dry-run")
+	createCmd.Flags().MarkHidden("simulate")
+	createCmd.Flags().Int("concurrency", 1, "Number of repositories to process in parallel")
+	createCmd.Flags().Duration("delay-between", 0, "Minimum time between two issue creations across all workers, e.g. 2s")
+	createCmd.Flags().Int("max-retries", 3, "Maximum retries when GitHub asks to back off with Retry-After")
+	createCmd.Flags().Int("max-failures", -1, "Abort the remaining repositories once more than this many have failed (-1 for unlimited)")
+	createCmd.Flags().Bool("continue-on-auth-error", false, "Keep going after authentication has failed for several repositories in a row (optional)")
+	createCmd.Flags().StringArray("subscribe", nil, "Users to subscribe to each created issue via a mention; repeatable and comma-separated (optional)")
+	createCmd.Flags().String("add-to-project", "", "Node ID of a Projects board (e.g. PVT_kwDO...) to add each created issue to (optional)")
+	createCmd.Flags().String("convert-to-discussion", "", "Move each created issue to a discussion in this category (optional)")
+	createCmd.Flags().Bool("create-locked", false, "Lock each created issue right after creating it so it is read-only (optional)")
+	createCmd.Flags().String("lock-reason", "", "Lock reason for --create-locked or --announce: off-topic, too heated, resolved or spam (optional)")
+	createCmd.Flags().Bool("announce", false, "Lock each created issue as off-topic so it is read-only; same as --create-locked --lock-reason off-topic (optional)")
+	createCmd.Flags().Bool("pin", false, "Also pin each created issue; requires --announce (optional)")
 
 	// Add commands
 	rootCmd.AddCommand(createCmd)
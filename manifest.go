@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest records the fully resolved targeting and content of a run so it can be reproduced
+type Manifest struct {
+	Org       string   `yaml:"org"`
+	Repos     []string `yaml:"repos"`
+	Title     string   `yaml:"title"`
+	Body      string   `yaml:"body"`
+	Labels    []string `yaml:"labels,omitempty"`
+	Assignees []string `yaml:"assignees,omitempty"`
+	Milestone string   `yaml:"milestone,omitempty"`
+}
+
+// manifestConflicts lists the create flags whose values a manifest already provides
+var manifestConflicts = []string{
+	"title", "description", "description-base64", "description-file", "description-url", "workflow-run-url",
+	"title-from-first-line", "title-prefix", "title-suffix", "labels", "assignees", "milestone",
+	"tasks-file", "attachments", "team-assignees",
+	"repo", "repos", "repos-file", "repos-from-json", "repos-from-stdin", "interactive-repos",
+	"affiliation", "repos-all-orgs", "rerun-failed", "query-file", "depends-on", "repo-content-query", "project",
+}
+
+// writeManifest serializes a manifest to a YAML file
+func writeManifest(path string, m Manifest) error {
+	data, err := yaml.Marshal(&m)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// readManifest loads a manifest written by --write-manifest
+func readManifest(path string) (Manifest, error) {
+	var m Manifest
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return m, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+	// Unknown keys are rejected so a misspelled field is not silently ignored
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return m, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if m.Org == "" || m.Title == "" || m.Body == "" || len(m.Repos) == 0 {
+		return m, fmt.Errorf("invalid manifest %s: org, repos, title and body are required", path)
+	}
+	return m, nil
+}
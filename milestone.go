@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var milestoneCmd = &cobra.Command{
+	Use:   "milestone",
+	Short: "Set or clear the milestone of an existing issue in each repository",
+	Long: `Set or clear the milestone of an existing issue in each repository.
+The milestone is resolved by title among the open milestones of each repository;
+with --create-missing it is created where it does not exist yet.`,
+	RunE: runMilestone,
+}
+
+// MilestoneNotFoundError reports that a repository has no open milestone with the wanted title
+type MilestoneNotFoundError struct {
+	Title string
+	Repo  string
+}
+
+func (e *MilestoneNotFoundError) Error() string {
+	return fmt.Sprintf("milestone %q not found in %s", e.Title, e.Repo)
+}
+
+// ensureMilestone resolves the milestone in a repository, creating it when it is missing
+// and create is set
+func (ic *IssueCreator) ensureMilestone(repo string, create bool) (int, bool, error) {
+	number, err := ic.milestoneNumber(repo)
+	var notFound *MilestoneNotFoundError
+	if err == nil || !create || !errors.As(err, &notFound) {
+		return number, false, err
+	}
+
+	m, _, err := ic.issues.CreateMilestone(ic.ctx, ic.org, repo, &github.Milestone{Title: &ic.milestone})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create milestone %q in %s/%s: %w", ic.milestone, ic.org, repo, err)
+	}
+	return m.GetNumber(), true, nil
+}
+
+// SetMilestone sets the milestone of an issue by number
+func (ic *IssueCreator) SetMilestone(repo string, number, milestone int) error {
+	if _, _, err := ic.issues.Edit(ic.ctx, ic.org, repo, number, &github.IssueRequest{Milestone: &milestone}); err != nil {
+		return fmt.Errorf("failed to set the milestone of %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+	return nil
+}
+
+// ClearMilestone removes the milestone from an issue
+func (ic *IssueCreator) ClearMilestone(repo string, number int) error {
+	if _, _, err := ic.issues.RemoveMilestone(ic.ctx, ic.org, repo, number); err != nil {
+		return fmt.Errorf("failed to clear the milestone of %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+	return nil
+}
+
+func runMilestone(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	numbers, err := issueNumbersFromFlags()
+	if err != nil {
+		return err
+	}
+	milestone := viper.GetString("milestone")
+	remove := viper.GetBool("clear")
+	createMissing := viper.GetBool("create-missing")
+	dryRun := viper.GetBool("dry-run")
+
+	if org == "" || !numbers.isSet() || (milestone == "" && !remove) {
+		return fmt.Errorf("missing required arguments: --org (or --repo), --issue-number (or --numbers-from) and --milestone (or --clear) are required")
+	}
+	if milestone != "" && remove {
+		return fmt.Errorf("--milestone and --clear cannot be used together")
+	}
+	if createMissing && remove {
+		return fmt.Errorf("--create-missing can only be used together with --milestone")
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+	creator.milestone = milestone
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	updated := 0
+	skipped := 0
+	failed := 0
+	for _, repo := range repoList {
+		number := numbers.forRepo(repo)
+		if number == 0 {
+			fmt.Fprintf(creator.out, "%s/%s: - (skipped: not in --numbers-from)\n", org, repo)
+			skipped++
+			continue
+		}
+		if remove {
+			fmt.Fprintf(creator.out, "Clearing the milestone of %s/%s#%d... ", org, repo, number)
+		} else {
+			fmt.Fprintf(creator.out, "Setting milestone %q on %s/%s#%d... ", milestone, org, repo, number)
+		}
+		if dryRun {
+			fmt.Fprintln(creator.out, markOK(), "(dry run)")
+			updated++
+			continue
+		}
+
+		if remove {
+			err = creator.ClearMilestone(repo, number)
+		} else {
+			var m int
+			var created bool
+			m, created, err = creator.ensureMilestone(repo, createMissing)
+			if err == nil {
+				if created {
+					fmt.Fprintf(creator.out, "(created milestone) ")
+				}
+				err = creator.SetMilestone(repo, number, m)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+			failed++
+			continue
+		}
+		fmt.Fprintln(creator.out, markOK())
+		updated++
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	summary := fmt.Sprintf("Summary: %d updated", updated)
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+	fmt.Fprintf(creator.out, "%s, %d failed\n", summary, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	addRepoFlags(milestoneCmd)
+	milestoneCmd.Flags().IntP("issue-number", "n", 0, "Issue number to update in each repository")
+	milestoneCmd.Flags().String("numbers-from", "", "Update the issue of each repository in this repo: number mapping written by create --numbers-out")
+	milestoneCmd.Flags().String("milestone", "", "Title of the open milestone to set")
+	milestoneCmd.Flags().Bool("create-missing", false, "Create the milestone in repositories that do not have it")
+	milestoneCmd.Flags().Bool("clear", false, "Remove the milestone instead of setting one")
+	milestoneCmd.Flags().Bool("dry-run", false, "Show which issues would be updated without changing them")
+
+	rootCmd.AddCommand(milestoneCmd)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// writeNumbers writes the issue number of each repository that has one, created or
+// found as a duplicate, as a repo: number mapping. Files ending in .json are written
+// as JSON, all others as YAML. It returns how many repositories were written.
+func writeNumbers(path string, results []RepoResult) (int, error) {
+	numbers := map[string]int{}
+	for _, result := range results {
+		if result.Issue != 0 {
+			numbers[result.Repo] = result.Issue
+		}
+	}
+
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err = json.MarshalIndent(numbers, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(numbers)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode issue numbers: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return 0, fmt.Errorf("failed to write issue numbers %s: %w", path, err)
+	}
+	return len(numbers), nil
+}
+
+// readNumbers reads a repo: number mapping written by --numbers-out, YAML or JSON
+func readNumbers(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue numbers %s: %w", path, err)
+	}
+	var numbers map[string]int
+	if err := yaml.Unmarshal(data, &numbers); err != nil {
+		return nil, fmt.Errorf("failed to parse issue numbers %s: %w", path, err)
+	}
+	for repo, number := range numbers {
+		if number <= 0 {
+			return nil, fmt.Errorf("invalid issue numbers %s: %s has number %d", path, repo, number)
+		}
+	}
+	return numbers, nil
+}
+
+// numbersRepos returns the repositories of a --numbers-from mapping in name order
+func numbersRepos(path string) ([]string, error) {
+	numbers, err := readNumbers(path)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]string, 0, len(numbers))
+	for repo := range numbers {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// issueNumbers is the issue to act on in each repository: the same --issue-number
+// everywhere or the per-repository numbers of a --numbers-from mapping
+type issueNumbers struct {
+	number int
+	byRepo map[string]int
+}
+
+// issueNumbersFromFlags reads --issue-number or --numbers-from; at most one may be set
+func issueNumbersFromFlags() (issueNumbers, error) {
+	numbers := issueNumbers{number: viper.GetInt("issue-number")}
+	path := viper.GetString("numbers-from")
+	if path == "" {
+		return numbers, nil
+	}
+	if numbers.number > 0 {
+		return numbers, fmt.Errorf("--issue-number and --numbers-from cannot be used together")
+	}
+	var err error
+	numbers.byRepo, err = readNumbers(path)
+	return numbers, err
+}
+
+// isSet reports whether an issue number was given in either way
+func (n issueNumbers) isSet() bool {
+	return n.number > 0 || n.byRepo != nil
+}
+
+// forRepo returns the issue number for a repository, or 0 when it is not in the mapping
+func (n issueNumbers) forRepo(repo string) int {
+	if n.byRepo != nil {
+		return n.byRepo[repo]
+	}
+	return n.number
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// runningInActions reports whether the tool runs inside a GitHub Actions job
+func runningInActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// requestActionsIDToken fetches an OIDC ID token for the current Actions job.
+// The job needs the "id-token: write" permission for the request variables to be set.
+func requestActionsIDToken(client *http.Client, audience string) (string, error) {
+	requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL")
+	requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("OIDC is not available in this job; grant the workflow \"id-token: write\" permission")
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid ACTIONS_ID_TOKEN_REQUEST_URL: %w", err)
+	}
+	if audience != "" {
+		q := u.Query()
+		q.Set("audience", audience)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+
+	var out struct {
+		Value string `json:"value"`
+	}
+	if err := doTokenRequest(client, req, &out); err != nil {
+		return "", fmt.Errorf("failed to request OIDC token: %w", err)
+	}
+	return out.Value, nil
+}
+
+// exchangeOIDCToken trades an OIDC ID token for a GitHub token at a token broker.
+// The broker is called with the ID token as bearer and must answer with {"token": "..."}.
+func exchangeOIDCToken(client *http.Client, exchangeURL, idToken string) (string, error) {
+	req, err := http.NewRequest(http.MethodPost, exchangeURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid --oidc-exchange-url: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+idToken)
+
+	var out struct {
+		Token string `json:"token"`
+	}
+	if err := doTokenRequest(client, req, &out); err != nil {
+		return "", fmt.Errorf("failed to exchange OIDC token: %w", err)
+	}
+	return out.Token, nil
+}
+
+// doTokenRequest sends a token request and decodes the JSON answer into out
+func doTokenRequest(client *http.Client, req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// oidcToken obtains a GitHub token through the OIDC exchange configured by flags
+func oidcToken(exchangeURL string) (string, error) {
+	if !runningInActions() {
+		return "", fmt.Errorf("--oidc-exchange-url is only supported inside GitHub Actions")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	idToken, err := requestActionsIDToken(client, viper.GetString("oidc-audience"))
+	if err != nil {
+		return "", err
+	}
+	token, err := exchangeOIDCToken(client, exchangeURL, idToken)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("failed to exchange OIDC token: the response contained no token")
+	}
+	return token, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+)
+
+var orgsCmd = &cobra.Command{
+	Use:   "orgs",
+	Short: "List the organizations the token's user belongs to",
+	Long: `List the organizations the token's user belongs to, with their repository counts.
+Use it to find the right --org value and to verify access before a campaign.
+Private repositories are only counted where the user may see the count.`,
+	RunE: runOrgs,
+}
+
+// ListOrganizations returns the organizations of the token's user
+func (ic *IssueCreator) ListOrganizations() ([]*github.Organization, error) {
+	opts := ic.listOptions()
+
+	var orgs []*github.Organization
+	for {
+		page, resp, err := ic.client.Organizations.List(ic.ctx, "", &opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizations: %w", err)
+		}
+		orgs = append(orgs, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return orgs, nil
+}
+
+func runOrgs(cmd *cobra.Command, args []string) error {
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, "", "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	orgs, err := creator.ListOrganizations()
+	if err != nil {
+		return err
+	}
+	if len(orgs) == 0 {
+		fmt.Fprintln(creator.out, "The token's user does not belong to any organization visible to this token")
+		return nil
+	}
+
+	failed := 0
+	for _, org := range orgs {
+		// The list only has logins; the repository counts come with the full organization
+		full, _, err := creator.client.Organizations.Get(creator.ctx, org.GetLogin())
+		if err != nil {
+			fmt.Fprintf(creator.out, "%s %s (%v)\n", org.GetLogin(), markFail(), err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(creator.out, "%-30s %d repositories (%d public, %d private)\n", full.GetLogin(),
+			int64(full.GetPublicRepos())+full.GetTotalPrivateRepos(), full.GetPublicRepos(), full.GetTotalPrivateRepos())
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	fmt.Fprintf(creator.out, "Summary: %d organizations\n", len(orgs))
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(orgsCmd)
+}
@@ -0,0 +1,23 @@
+package main
+
+import "fmt"
+
+// PermissionError reports a repository the token can see but cannot create issues in
+type PermissionError struct {
+	Repo string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("no permission to create issues in %s", e.Repo)
+}
+
+// hasWriteAccess reports whether the token has triage access or more to a repository,
+// according to the permissions GitHub returns with the repository
+func (ic *IssueCreator) hasWriteAccess(repo string) (bool, error) {
+	r, _, err := ic.repos.Get(ic.ctx, ic.org, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permissions on %s/%s: %w", ic.org, repo, err)
+	}
+	perms := r.GetPermissions()
+	return perms["triage"] || perms["push"] || perms["maintain"] || perms["admin"], nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check connectivity and authentication against the GitHub API",
+	RunE:  runPing,
+}
+
+func runPing(cmd *cobra.Command, args []string) error {
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return fmt.Errorf("GitHub token is required. Set GITHUB_TOKEN env var or use --token flag")
+	}
+
+	ctx := context.Background()
+	client, err := newGitHubClient(ctx, token, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	// Report the proxy the default transport would pick for the API endpoint
+	fmt.Printf("Endpoint: %s\n", client.BaseURL)
+	proxy, err := http.ProxyFromEnvironment(&http.Request{URL: client.BaseURL})
+	if err != nil {
+		return fmt.Errorf("invalid proxy configuration: %v", err)
+	}
+	if proxy != nil {
+		fmt.Printf("Proxy: %s\n", proxy.Redacted())
+	} else {
+		fmt.Println("Proxy: none")
+	}
+
+	start := time.Now()
+	limits, resp, err := client.RateLimit.Get(ctx)
+	elapsed := time.Since(start)
+	if err != nil {
+		if resp != nil {
+			fmt.Printf("Reachable: yes (HTTP %d in %s)\n", resp.StatusCode, elapsed.Round(time.Millisecond))
+		} else {
+			fmt.Println("Reachable: no")
+		}
+		return fmt.Errorf("ping failed: %v", err)
+	}
+
+	fmt.Printf("Reachable: yes (HTTP %d in %s)\n", resp.StatusCode, elapsed.Round(time.Millisecond))
+	if state := resp.TLS; state != nil {
+		fmt.Printf("TLS: %s, %s\n", tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite))
+	} else {
+		fmt.Println("TLS: not used")
+	}
+	if core := limits.GetCore(); core != nil {
+		fmt.Printf("Authenticated: yes (%d/%d requests remaining, resets at %s)\n",
+			core.Remaining, core.Limit, core.Reset.Format(time.RFC3339))
+	}
+	if scopes, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		fmt.Printf("Scopes: %s\n", strings.Join(scopes, ", "))
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(pingCmd)
+}
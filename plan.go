@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show which target repositories are missing the campaign issue",
+	Long: `Show which target repositories are missing the campaign issue.
+Campaign issues are found by label and/or a marker text in the body, like the status command,
+and compared with the target repositories. Repositories marked + have no campaign issue yet;
+write them with --missing-out and pass that file to create --repos-file.`,
+	RunE: runPlan,
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	label := viper.GetString("label")
+	marker := viper.GetString("marker")
+
+	if org == "" || (label == "" && marker == "") {
+		return fmt.Errorf("missing required arguments: --org (or --repo) and one of --label or --marker are required")
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	query := campaignQuery(org, label, marker, true)
+	fmt.Fprintf(creator.out, "Searching: %s\n", query)
+	issues, total, err := creator.SearchIssues(query)
+	if err != nil {
+		return err
+	}
+
+	// Closed campaign issues count as present; the campaign is done there
+	existing := map[string][]string{}
+	for _, issue := range issues {
+		repo := path.Base(issue.GetRepositoryURL())
+		existing[repo] = append(existing[repo], fmt.Sprintf("#%d %s", issue.GetNumber(), issue.GetState()))
+	}
+
+	var missing []string
+	fmt.Fprintln(creator.out, "---")
+	for _, repo := range repoList {
+		if found := existing[repo]; len(found) > 0 {
+			fmt.Fprintf(creator.out, "  = %s/%s (%s)\n", org, repo, strings.Join(found, ", "))
+			continue
+		}
+		fmt.Fprintf(creator.out, "  + %s/%s\n", org, repo)
+		missing = append(missing, repo)
+	}
+	fmt.Fprintln(creator.out, "---")
+	fmt.Fprintf(creator.out, "Plan: %d to create, %d up to date\n", len(missing), len(repoList)-len(missing))
+	if total > len(issues) {
+		fmt.Fprintf(creator.out, "Note: only %d of %d matching issues could be fetched from the search API; "+
+			"some repositories may be wrongly listed as missing\n", len(issues), total)
+	}
+
+	if missingOut := viper.GetString("missing-out"); missingOut != "" {
+		content := ""
+		for _, repo := range missing {
+			content += repo + "\n"
+		}
+		if err := os.WriteFile(missingOut, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", missingOut, err)
+		}
+		fmt.Fprintf(creator.out, "Missing repositories written to %s; create them with --repos-file %s\n", missingOut, missingOut)
+	}
+
+	return nil
+}
+
+func init() {
+	addRepoFlags(planCmd)
+	planCmd.Flags().String("label", "", "Label that marks the campaign issues")
+	planCmd.Flags().String("marker", "", "Text that marks the campaign issues in their body")
+	planCmd.Flags().String("missing-out", "", "Write the repositories missing the campaign issue to this file, one per line (optional)")
+
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,37 @@
+package main
+
+import "sync"
+
+// runPool calls work for the indexes 0 to n-1 on up to workers goroutines and waits
+// for all of them. When stop is set it is called before each index is handed out with
+// that index; once it returns true no further indexes are started. It runs the
+// repositories of create with --concurrency (copy shares that loop with one worker), the
+// checks of labels audit with --concurrency, and the organization repository listing
+// with --list-concurrency. The other commands process repositories one
+// at a time.
+func runPool(n, workers int, stop func(next int) bool, work func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				work(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		if stop != nil && stop(i) {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/viper"
+)
+
+// fakeIssues implements the IssuesAPI calls made while creating an issue. Methods
+// that are not overridden panic through the nil embedded interface.
+type fakeIssues struct {
+	IssuesAPI
+
+	// createErr fails Create for the repositories it lists
+	createErr map[string]error
+
+	// answers are returned by Create in a repository, one per call, before it succeeds
+	answers map[string][]*github.ErrorResponse
+
+	// open are the open issues ListByRepo returns per repository
+	open map[string][]*github.Issue
+
+	mu      sync.Mutex
+	calls   map[string]int
+	created []string
+}
+
+func (f *fakeIssues) Create(ctx context.Context, owner, repo string, req *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[repo]++
+	if answers := f.answers[repo]; len(answers) > 0 {
+		f.answers[repo] = answers[1:]
+		return nil, &github.Response{Response: answers[0].Response}, answers[0]
+	}
+	if err := f.createErr[repo]; err != nil {
+		return nil, nil, err
+	}
+	f.created = append(f.created, repo)
+	return &github.Issue{
+		Number:  github.Int(len(f.created)),
+		Title:   req.Title,
+		HTMLURL: github.String("https://github.com/" + owner + "/" + repo + "/issues/1"),
+	}, nil, nil
+}
+
+func (f *fakeIssues) ListByRepo(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	var issues []*github.Issue
+	for _, issue := range f.open[repo] {
+		if len(opts.Labels) == 0 {
+			issues = append(issues, issue)
+		}
+		for _, label := range issue.Labels {
+			if len(opts.Labels) > 0 && label.GetName() == opts.Labels[0] {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, &github.Response{}, nil
+}
+
+// errorAnswer builds the error Create returns for an HTTP status and response headers
+func errorAnswer(status int, header http.Header) *github.ErrorResponse {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &github.ErrorResponse{
+		Response: &http.Response{StatusCode: status, Header: header, Request: &http.Request{Method: http.MethodPost}},
+		Message:  http.StatusText(status),
+	}
+}
+
+// fakeRepos implements the RepositoriesAPI lookup of repositories that answered 410
+type fakeRepos struct {
+	RepositoriesAPI
+
+	// current is the repository Get finds under each name; missing names are 404
+	current map[string]*github.Repository
+}
+
+func (f *fakeRepos) Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	if current, ok := f.current[repo]; ok {
+		return current, &github.Response{}, nil
+	}
+	return nil, nil, errorAnswer(http.StatusNotFound, nil)
+}
+
+// newFakeCreator returns an IssueCreator for org "acme" that talks to issues
+func newFakeCreator(issues IssuesAPI) *IssueCreator {
+	return &IssueCreator{
+		issues:      issues,
+		ctx:         context.Background(),
+		org:         "acme",
+		title:       "Upgrade CI",
+		desc:        "Please upgrade the CI configuration.",
+		perPage:     maxPerPage,
+		out:         io.Discard,
+		maxFailures: -1,
+	}
+}
+
+func TestProcessRepoCreated(t *testing.T) {
+	issues := &fakeIssues{}
+	ic := newFakeCreator(issues)
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusCreated {
+		t.Fatalf("status = %q, want %q (error %q)", result.Status, StatusCreated, result.Error)
+	}
+	if result.Issue != 1 || result.URL == "" {
+		t.Errorf("issue = %d, url = %q, want the created issue", result.Issue, result.URL)
+	}
+	if len(issues.created) != 1 || issues.created[0] != "api" {
+		t.Errorf("created in %q, want [api]", issues.created)
+	}
+}
+
+func TestProcessRepoFailed(t *testing.T) {
+	issues := &fakeIssues{createErr: map[string]error{"api": errors.New("boom")}}
+	ic := newFakeCreator(issues)
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusFailed {
+		t.Fatalf("status = %q, want %q", result.Status, StatusFailed)
+	}
+	if result.Error == "" {
+		t.Error("failed result has no error")
+	}
+}
+
+func TestProcessRepoDuplicate(t *testing.T) {
+	ic := newFakeCreator(nil)
+	ic.dedupLabel = dedupLabel(ic.issueTitle(), ic.issueBody())
+	issues := &fakeIssues{open: map[string][]*github.Issue{
+		"api": {{Number: github.Int(7), Labels: []*github.Label{{Name: github.String(ic.dedupLabel)}}}},
+	}}
+	ic.issues = issues
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusSkipped {
+		t.Fatalf("status = %q, want %q (error %q)", result.Status, StatusSkipped, result.Error)
+	}
+	if len(issues.created) != 0 {
+		t.Errorf("created in %q, want no new issue for a duplicate", issues.created)
+	}
+}
+
+func TestProcessRepoDuplicateIgnoresPullRequests(t *testing.T) {
+	ic := newFakeCreator(nil)
+	ic.dedupLabel = dedupLabel(ic.issueTitle(), ic.issueBody())
+	labels := []*github.Label{{Name: github.String(ic.dedupLabel)}}
+	issues := &fakeIssues{open: map[string][]*github.Issue{
+		"api": {{Number: github.Int(7), Labels: labels, PullRequestLinks: &github.PullRequestLinks{}}},
+	}}
+	ic.issues = issues
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusCreated {
+		t.Fatalf("status = %q, want %q for a labeled pull request (error %q)", result.Status, StatusCreated, result.Error)
+	}
+}
+
+func TestProcessRepoSkipsSameTitle(t *testing.T) {
+	issues := &fakeIssues{open: map[string][]*github.Issue{
+		"api": {
+			{Number: github.Int(4), Title: github.String("Upgrade CI"), PullRequestLinks: &github.PullRequestLinks{}},
+			{Number: github.Int(5), Title: github.String("Upgrade CI tooling")},
+			{Number: github.Int(6), Title: github.String("Upgrade CI")},
+		},
+	}}
+	ic := newFakeCreator(issues)
+	ic.skipDuplicates = true
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusSkipped || result.Issue != 6 {
+		t.Fatalf("status = %q, issue = %d, want %q of #6 (error %q)", result.Status, result.Issue, StatusSkipped, result.Error)
+	}
+	if len(issues.created) != 0 {
+		t.Errorf("created in %q, want no new issue for a duplicate", issues.created)
+	}
+}
+
+func TestCreateIssuesInRepositoriesCounts(t *testing.T) {
+	issues := &fakeIssues{createErr: map[string]error{"web": errors.New("boom")}}
+	ic := newFakeCreator(issues)
+
+	results, success, failed := ic.CreateIssuesInRepositories([]string{"api", "web", "docs"})
+	if success != 2 || failed != 1 {
+		t.Errorf("success = %d, failed = %d, want 2 and 1", success, failed)
+	}
+	want := []string{StatusCreated, StatusFailed, StatusCreated}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("%s: status = %q, want %q", result.Repo, result.Status, want[i])
+		}
+	}
+}
+
+func TestCreateIssuesInRepositoriesAbortKeepsUnprocessed(t *testing.T) {
+	issues := &fakeIssues{createErr: map[string]error{"api": errors.New("boom")}}
+	ic := newFakeCreator(issues)
+	ic.maxFailures = 0
+	ic.concurrency = 1
+
+	results, _, failed := ic.CreateIssuesInRepositories([]string{"api", "web", "docs"})
+	if failed != 1 || len(results) != 3 {
+		t.Fatalf("failed = %d with %d results, want 1 failure and 3 results", failed, len(results))
+	}
+	for _, result := range results[1:] {
+		if result.Status != StatusNotProcessed {
+			t.Errorf("%s: status = %q, want %q", result.Repo, result.Status, StatusNotProcessed)
+		}
+	}
+}
+
+func TestProcessRepoRetriesSecondaryRateLimit(t *testing.T) {
+	issues := &fakeIssues{answers: map[string][]*github.ErrorResponse{
+		"api": {errorAnswer(http.StatusForbidden, http.Header{"Retry-After": {"0"}})},
+	}}
+	ic := newFakeCreator(issues)
+	ic.maxRetries = 1
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusCreated {
+		t.Fatalf("status = %q, want %q (error %q)", result.Status, StatusCreated, result.Error)
+	}
+	if issues.calls["api"] != 2 {
+		t.Errorf("Create called %d times, want 2", issues.calls["api"])
+	}
+}
+
+func TestProcessRepoRetriesExhausted(t *testing.T) {
+	limited := errorAnswer(http.StatusForbidden, http.Header{"Retry-After": {"0"}})
+	issues := &fakeIssues{answers: map[string][]*github.ErrorResponse{"api": {limited, limited}}}
+	ic := newFakeCreator(issues)
+	ic.maxRetries = 1
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusFailed {
+		t.Fatalf("status = %q, want %q", result.Status, StatusFailed)
+	}
+	if issues.calls["api"] != 2 {
+		t.Errorf("Create called %d times, want 2", issues.calls["api"])
+	}
+}
+
+func TestProcessRepoForbiddenIsNoPermission(t *testing.T) {
+	issues := &fakeIssues{answers: map[string][]*github.ErrorResponse{
+		"api": {errorAnswer(http.StatusForbidden, nil)},
+	}}
+	ic := newFakeCreator(issues)
+	ic.maxRetries = 3
+
+	result, _ := ic.processRepo(0, "api")
+	if result.Status != StatusNoPermission {
+		t.Fatalf("status = %q, want %q (error %q)", result.Status, StatusNoPermission, result.Error)
+	}
+	if issues.calls["api"] != 1 {
+		t.Errorf("Create called %d times, want no retry without Retry-After", issues.calls["api"])
+	}
+}
+
+func TestProcessRepoGone(t *testing.T) {
+	tests := []struct {
+		name    string
+		current map[string]*github.Repository
+		status  string
+		movedTo string
+	}{
+		{"deleted", nil, StatusGone, ""},
+		{"issues disabled", map[string]*github.Repository{
+			"api": {FullName: github.String("acme/api"), HasIssues: github.Bool(false)},
+		}, StatusGone, ""},
+		{"transferred", map[string]*github.Repository{
+			"api": {FullName: github.String("other/api")},
+		}, StatusGone, "other/api"},
+		{"renamed", map[string]*github.Repository{
+			"api": {FullName: github.String("acme/api-v2")},
+		}, StatusCreated, "acme/api-v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := &fakeIssues{answers: map[string][]*github.ErrorResponse{
+				"api": {errorAnswer(http.StatusGone, nil)},
+			}}
+			ic := newFakeCreator(issues)
+			ic.repos = &fakeRepos{current: tt.current}
+
+			result, _ := ic.processRepo(0, "api")
+			if result.Status != tt.status || result.MovedTo != tt.movedTo {
+				t.Fatalf("status = %q, moved to %q, want %q and %q (error %q)",
+					result.Status, result.MovedTo, tt.status, tt.movedTo, result.Error)
+			}
+			if tt.status == StatusCreated && (len(issues.created) != 1 || issues.created[0] != "api-v2") {
+				t.Errorf("created in %q, want [api-v2]", issues.created)
+			}
+		})
+	}
+}
+
+func TestCreateIssuesInRepositoriesConcurrent(t *testing.T) {
+	repos := make([]string, 40)
+	createErr := map[string]error{}
+	for i := range repos {
+		repos[i] = fmt.Sprintf("repo-%02d", i)
+		if i%10 == 0 {
+			createErr[repos[i]] = errors.New("boom")
+		}
+	}
+	issues := &fakeIssues{createErr: createErr}
+	ic := newFakeCreator(issues)
+	ic.concurrency = 8
+
+	results, success, failed := ic.CreateIssuesInRepositories(repos)
+	if success != 36 || failed != 4 {
+		t.Errorf("success = %d, failed = %d, want 36 and 4", success, failed)
+	}
+	if len(issues.created) != 36 {
+		t.Errorf("created %d issues, want 36", len(issues.created))
+	}
+	for i, result := range results {
+		want := StatusCreated
+		if createErr[repos[i]] != nil {
+			want = StatusFailed
+		}
+		if result.Repo != repos[i] || result.Status != want {
+			t.Errorf("result %d = %s %q, want %s %q", i, result.Repo, result.Status, repos[i], want)
+		}
+	}
+}
+
+func TestResolveOrgReposRejectsOtherOwners(t *testing.T) {
+	ic := newFakeCreator(nil)
+
+	viper.Set("repos", "api,acme/web")
+	defer viper.Set("repos", nil)
+	repos, err := resolveOrgRepos(ic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 || repos[0] != "api" || repos[1] != "web" {
+		t.Errorf("repos = %q, want [api web]", repos)
+	}
+
+	viper.Set("repos", "api,other/web")
+	if _, err := resolveOrgRepos(ic); err == nil {
+		t.Error("resolveOrgRepos accepted a repository of another owner")
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// progress tracks how many repositories have been processed and estimates the time
+// remaining from the observed wall-clock time per repository. Using wall-clock time
+// accounts for concurrency, retries and rate-limit pauses alike.
+type progress struct {
+	total int
+	done  int
+	start time.Time
+}
+
+func newProgress(total int) *progress {
+	return &progress{total: total, start: time.Now()}
+}
+
+// eta returns the estimated time until all repositories are processed
+func (p *progress) eta() time.Duration {
+	if p.done == 0 {
+		return 0
+	}
+	perRepo := time.Since(p.start) / time.Duration(p.done)
+	return perRepo * time.Duration(p.total-p.done)
+}
+
+// advance marks one more repository as processed and returns the progress prefix
+// for its output line, e.g. "[3/10, ETA 1m20s] "
+func (p *progress) advance() string {
+	p.done++
+	if p.done >= p.total {
+		return fmt.Sprintf("[%d/%d] ", p.done, p.total)
+	}
+	return fmt.Sprintf("[%d/%d, ETA %s] ", p.done, p.total, formatETA(p.eta()))
+}
+
+// formatETA rounds an estimate to a precision that fits its size
+func formatETA(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return d.Round(time.Second).String()
+	case d < time.Hour:
+		return d.Round(10 * time.Second).String()
+	default:
+		return d.Round(time.Minute).String()
+	}
+}
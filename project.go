@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// projectItemsQuery pages through the items of an organization's Projects (v2) board
+// and returns the repository of every issue and pull request on it
+const projectItemsQuery = `query($org: String!, $number: Int!, $cursor: String) {
+  organization(login: $org) {
+    projectV2(number: $number) {
+      items(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          content {
+            ... on Issue { repository { name owner { login } } }
+            ... on PullRequest { repository { name owner { login } } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// projectItemsPage is the part of projectItemsQuery's response that is used
+type projectItemsPage struct {
+	Organization struct {
+		ProjectV2 *struct {
+			Items struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []struct {
+					Content *struct {
+						Repository *struct {
+							Name  string `json:"name"`
+							Owner struct {
+								Login string `json:"login"`
+							} `json:"owner"`
+						} `json:"repository"`
+					} `json:"content"`
+				} `json:"nodes"`
+			} `json:"items"`
+		} `json:"projectV2"`
+	} `json:"organization"`
+}
+
+// ProjectRepositories returns the repositories referenced by the items of the organization's
+// project board, in board order without duplicates. Draft issues have no repository and
+// are ignored; repositories owned by another account are reported and left out.
+func (ic *IssueCreator) ProjectRepositories(number int) ([]string, error) {
+	var repos []string
+	seen := map[string]bool{}
+	vars := map[string]interface{}{"org": ic.org, "number": number}
+
+	for {
+		var page projectItemsPage
+		if err := ic.graphQL(projectItemsQuery, vars, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch project %d of %s: %w", number, ic.org, err)
+		}
+		project := page.Organization.ProjectV2
+		if project == nil {
+			return nil, fmt.Errorf("project %d not found in organization %s", number, ic.org)
+		}
+
+		for _, node := range project.Items.Nodes {
+			if node.Content == nil || node.Content.Repository == nil {
+				continue
+			}
+			repo := node.Content.Repository
+			if repo.Owner.Login != ic.org {
+				key := repo.Owner.Login + "/" + repo.Name
+				if !seen[key] {
+					seen[key] = true
+					fmt.Fprintf(os.Stderr, "Warning: skipping %s on project %d: not in organization %s\n", key, number, ic.org)
+				}
+				continue
+			}
+			if !seen[repo.Name] {
+				seen[repo.Name] = true
+				repos = append(repos, repo.Name)
+			}
+		}
+
+		if !project.Items.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = project.Items.PageInfo.EndCursor
+	}
+	return repos, nil
+}
+
+// AddToProject adds an issue to a Projects (v2) board given the board's node ID
+func (ic *IssueCreator) AddToProject(projectID string, issue *github.Issue) error {
+	const mutation = `mutation($project: ID!, $content: ID!) {
+  addProjectV2ItemById(input: {projectId: $project, contentId: $content}) { item { id } }
+}`
+	vars := map[string]interface{}{"project": projectID, "content": issue.GetNodeID()}
+	if err := ic.graphQL(mutation, vars, nil); err != nil {
+		return fmt.Errorf("failed to add issue #%d to project %s: %w", issue.GetNumber(), projectID, err)
+	}
+	return nil
+}
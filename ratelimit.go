@@ -0,0 +1,98 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// rateLimiter is a token bucket shared by all workers. It holds the requests
+// remaining in the current rate-limit window and refills when the window resets.
+// The bucket is sized from the rate-limit headers of each response, so requests
+// made by other clients with the same token are accounted for as well.
+type rateLimiter struct {
+	mu        sync.Mutex
+	known     bool
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// Wait blocks until a request may be sent without exceeding the rate limit
+func (l *rateLimiter) Wait() {
+	for {
+		l.mu.Lock()
+		if !l.known || l.remaining > 0 {
+			if l.known {
+				l.remaining--
+			}
+			l.mu.Unlock()
+			return
+		}
+
+		wait := time.Until(l.reset)
+		if wait <= 0 {
+			// The window has reset; refill the bucket
+			l.remaining = l.limit
+			l.mu.Unlock()
+			continue
+		}
+		l.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
+
+// Update resizes the bucket from the rate reported with a response
+func (l *rateLimiter) Update(rate github.Rate) {
+	if rate.Limit == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.known || rate.Reset.After(l.reset) {
+		// First response or a new window
+		l.known = true
+		l.limit = rate.Limit
+		l.remaining = rate.Remaining
+		l.reset = rate.Reset.Time
+		return
+	}
+	if rate.Remaining < l.remaining {
+		l.remaining = rate.Remaining
+	}
+}
+
+// pacer spaces out issue creations by a fixed delay across all workers, so the
+// global creation rate is bounded no matter the concurrency. Unlike rateLimiter it
+// exists to spare watchers a burst of notifications, not to stay within API limits.
+type pacer struct {
+	mu    sync.Mutex
+	delay time.Duration
+	next  time.Time
+}
+
+// Wait blocks until the delay has passed since the previous creation
+func (p *pacer) Wait() {
+	if p.delay <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	wait := p.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	p.next = now.Add(wait + p.delay)
+	p.mu.Unlock()
+
+	time.Sleep(wait)
+}
+
+// perMinute is the highest number of creations per minute the delay allows
+func (p *pacer) perMinute() float64 {
+	return float64(time.Minute) / float64(p.delay)
+}
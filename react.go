@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var reactCmd = &cobra.Command{
+	Use:   "react",
+	Short: "Add a reaction to an existing issue in each repository",
+	Long: `Add a reaction to an existing issue in each repository, e.g. to acknowledge
+a campaign issue with a +1 from the token's user.`,
+	RunE: runReact,
+}
+
+// reactions are the reaction contents GitHub accepts on issues
+var reactions = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// ReactToIssue adds a reaction to an issue. It reports false when the token's user
+// had already reacted with the same content, in which case GitHub adds nothing.
+func (ic *IssueCreator) ReactToIssue(repo string, number int, content string) (bool, error) {
+	_, resp, err := ic.client.Reactions.CreateIssueReaction(ic.ctx, ic.org, repo, number, content)
+	if err != nil {
+		return false, fmt.Errorf("failed to react to %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+	return resp.StatusCode != http.StatusOK, nil
+}
+
+func runReact(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	numbers, err := issueNumbersFromFlags()
+	if err != nil {
+		return err
+	}
+	reaction := viper.GetString("reaction")
+	dryRun := viper.GetBool("dry-run")
+
+	if org == "" || !numbers.isSet() || reaction == "" {
+		return fmt.Errorf("missing required arguments: --org (or --repo), --issue-number (or --numbers-from) and --reaction are required")
+	}
+	valid := false
+	for _, r := range reactions {
+		valid = valid || r == reaction
+	}
+	if !valid {
+		return fmt.Errorf("--reaction must be one of %s", strings.Join(reactions, ", "))
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	reacted := 0
+	skipped := 0
+	failed := 0
+	for _, repo := range repoList {
+		number := numbers.forRepo(repo)
+		if number == 0 {
+			fmt.Fprintf(creator.out, "%s/%s: - (skipped: not in --numbers-from)\n", org, repo)
+			skipped++
+			continue
+		}
+		fmt.Fprintf(creator.out, "Reacting %s to %s/%s#%d... ", reaction, org, repo, number)
+		if dryRun {
+			fmt.Fprintln(creator.out, markOK(), "(dry run)")
+			reacted++
+			continue
+		}
+		added, err := creator.ReactToIssue(repo, number, reaction)
+		switch {
+		case err != nil:
+			fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+			failed++
+		case !added:
+			fmt.Fprintln(creator.out, "- (skipped: already reacted)")
+			skipped++
+		default:
+			fmt.Fprintln(creator.out, markOK())
+			reacted++
+		}
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	fmt.Fprintf(creator.out, "Summary: %d reacted, %d skipped, %d failed\n", reacted, skipped, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func init() {
+	addRepoFlags(reactCmd)
+	reactCmd.Flags().IntP("issue-number", "n", 0, "Issue number to react to in each repository")
+	reactCmd.Flags().String("numbers-from", "", "React to the issue of each repository in this repo: number mapping written by create --numbers-out")
+	reactCmd.Flags().String("reaction", "", "Reaction to add: +1, -1, laugh, confused, heart, hooray, rocket or eyes")
+	reactCmd.Flags().Bool("dry-run", false, "Show which issues would get the reaction without adding it")
+
+	rootCmd.AddCommand(reactCmd)
+}
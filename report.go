@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// markdownCell escapes text for use in a markdown table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// resultDetails returns the reason, error or warnings of a result for reports
+func resultDetails(result RepoResult) string {
+	details := []string{}
+	if result.Reason != "" {
+		details = append(details, result.Reason)
+	}
+	if result.Error != "" {
+		details = append(details, result.Error)
+	}
+	details = append(details, result.Warnings...)
+	return strings.Join(details, "; ")
+}
+
+// writeMarkdownReport writes the results of a run as a markdown table with a link to each issue
+func writeMarkdownReport(path, org string, results []RepoResult) error {
+	var b strings.Builder
+	b.WriteString("| Repository | Status | Issue | Details |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, result := range results {
+		issue := ""
+		if result.URL != "" {
+			issue = fmt.Sprintf("[#%d](%s)", result.Issue, result.URL)
+		}
+		fmt.Fprintf(&b, "| %s/%s | %s | %s | %s |\n",
+			org, result.Repo, result.Status, issue, markdownCell(resultDetails(result)))
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	return nil
+}
+
+// csvHeader is the header row of CSV reports
+var csvHeader = []string{"repo", "status", "issue", "url", "details"}
+
+// writeCSVReport writes the results of a run as CSV with one row per repository
+func writeCSVReport(path string, results []RepoResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write(csvHeader)
+	for _, result := range results {
+		issue := ""
+		if result.Issue != 0 {
+			issue = strconv.Itoa(result.Issue)
+		}
+		w.Write([]string{result.Repo, result.Status, issue, result.URL, resultDetails(result)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeFailures writes the names of the failed repositories, and of those an aborted run
+// never reached, one per line in the format read by --repos-file, and returns how many
+// there were. The file is written
+// even when nothing failed, so a rerun loop ends with an empty list.
+func writeFailures(path string, results []RepoResult) (int, error) {
+	var b strings.Builder
+	count := 0
+	for _, result := range results {
+		if needsRetry(result.Status) {
+			b.WriteString(result.Repo + "\n")
+			count++
+		}
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return 0, fmt.Errorf("failed to write failures %s: %w", path, err)
+	}
+	return count, nil
+}
+
+// writeResults writes the result of each processed repository as a JSON array of objects,
+// in the format read by --repos-from-json
+func writeResults(path string, results []RepoResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write results %s: %w", path, err)
+	}
+	return nil
+}
+
+// readFailedFromCSV returns the repositories with status "failed" or "not-processed" in a CSV report
+// written by --report-csv. The columns are found by the header row.
+func readFailedFromCSV(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	repoCol, statusCol := -1, -1
+	for i, name := range rows[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "repo":
+			repoCol = i
+		case "status":
+			statusCol = i
+		}
+	}
+	if repoCol < 0 || statusCol < 0 {
+		return nil, fmt.Errorf("%s has no repo and status columns", path)
+	}
+
+	var repos []string
+	for _, row := range rows[1:] {
+		if len(row) > repoCol && len(row) > statusCol && needsRetry(strings.TrimSpace(row[statusCol])) {
+			repos = append(repos, strings.TrimSpace(row[repoCol]))
+		}
+	}
+	return mergeLists(nil, repos), nil
+}
+
+// plannedRequest is one entry of a --requests-out file
+type plannedRequest struct {
+	Repo    string               `json:"repo"`
+	Request *github.IssueRequest `json:"request"`
+}
+
+// writeRequests writes the create requests of a dry run as a JSON array, one entry per
+// repository that would receive an issue
+func writeRequests(path, org string, results []RepoResult) error {
+	planned := []plannedRequest{}
+	for _, result := range results {
+		if result.request != nil {
+			planned = append(planned, plannedRequest{Repo: org + "/" + result.Repo, Request: result.request})
+		}
+	}
+
+	data, err := json.MarshalIndent(planned, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode requests: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteResultsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	results := []RepoResult{
+		{Repo: "api", Status: StatusCreated, Issue: 3, URL: "https://github.com/acme/api/issues/3"},
+		{Repo: "web", Status: StatusFailed, Error: "boom"},
+		{Repo: "docs", Status: StatusNotProcessed, Reason: "run aborted before this repository"},
+	}
+	if err := writeResults(path, results); err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := readReposFromJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"api", "web", "docs"}; !reflect.DeepEqual(repos, want) {
+		t.Errorf("readReposFromJSON = %q, want %q", repos, want)
+	}
+}
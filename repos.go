@@ -0,0 +1,560 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// addRepoFlags registers the flags that select the target organization and repositories
+func addRepoFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("org", "o", "", "GitHub organization name (required)")
+	cmd.Flags().StringP("repos", "r", "", "Comma-separated list of repository names; owner/name entries override --org for create (optional; if omitted, all repos in org are used)")
+	cmd.Flags().String("repos-file", "", "Read target repository names from a file, one per line (optional)")
+	cmd.Flags().Bool("repos-from-stdin", false, "Read target repository names from stdin, one per line (optional)")
+	cmd.Flags().String("repos-from-json", "", "Read target repository names from a JSON array of names or of objects with a repo or name field, such as a create --results-out file (optional)")
+	cmd.Flags().String("repo", "", "Single target repository as owner/name; replaces --org and --repos (optional)")
+	cmd.Flags().String("affiliation", "", "Target the token user's repositories with this affiliation: owner, collaborator and/or organization_member, comma-separated (optional)")
+	cmd.Flags().String("rerun-failed", "", "Target the repositories with status failed in a CSV report of a previous run (optional)")
+	cmd.Flags().String("query-file", "", "Target the union of the repositories found by the search queries in this file, one per line (optional)")
+	cmd.Flags().String("depends-on", "", "Target the repositories whose go.mod or package.json mentions this module or package, found by code search (optional)")
+	cmd.Flags().String("repo-content-query", "", "Target the repositories with files matching this code search query, e.g. 'actions/checkout@v2 path:.github/workflows' (optional)")
+	cmd.Flags().Int("project", 0, "Target the repositories of the issues and pull requests on this organization Projects board number (optional)")
+	cmd.Flags().Bool("interactive-repos", false, "Pick target repositories from the organization interactively (optional)")
+	cmd.Flags().Int("list-concurrency", 1, "Number of pages of organization repositories to fetch in parallel (optional)")
+	cmd.Flags().String("repo-regex", "", "Only target organization repositories whose name matches this regular expression (optional)")
+	cmd.Flags().String("repos-exclude-regex", "", "Skip organization repositories whose name matches this regular expression (optional)")
+	cmd.Flags().String("default-branch", "", "Only target organization repositories whose default branch has this name (optional)")
+	cmd.Flags().String("license", "", "Only target organization repositories with this SPDX license, e.g. MIT (optional)")
+	cmd.Flags().Bool("no-license", false, "Only target organization repositories without a detected license (optional)")
+	cmd.Flags().Int("min-open-issues", 0, "Only target organization repositories with at least this many open issues and pull requests (optional)")
+	cmd.Flags().StringArray("exclude-topic", nil, "Skip organization repositories tagged with any of these topics; repeatable and comma-separated (optional)")
+	cmd.Flags().Bool("preview-count-by-filter", false, "Print how many listed repositories each filter removed (optional)")
+}
+
+// parseRepoRef splits an owner/name repository reference
+func parseRepoRef(ref string) (string, string, error) {
+	owner, name, ok := strings.Cut(strings.TrimSpace(ref), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid repository %q: expected owner/name", ref)
+	}
+	return owner, name, nil
+}
+
+// resolveOrg returns the target organization, taking the owner from --repo when it is set
+func resolveOrg() (string, error) {
+	org := viper.GetString("org")
+	repo := viper.GetString("repo")
+	if repo == "" {
+		return org, nil
+	}
+
+	owner, _, err := parseRepoRef(repo)
+	if err != nil {
+		return "", err
+	}
+	if org != "" && org != owner {
+		return "", fmt.Errorf("--org %s conflicts with the owner of --repo %s", org, repo)
+	}
+	return owner, nil
+}
+
+// readReposFromJSON extracts repository names from a JSON file: a results file written by
+// --results-out, or any JSON array of names or of objects carrying a "repo" or "name" field.
+func readReposFromJSON(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: expected a JSON array: %w", path, err)
+	}
+
+	var repos []string
+	seen := map[string]bool{}
+	for i, entry := range entries {
+		var name string
+		if err := json.Unmarshal(entry, &name); err != nil {
+			var obj struct {
+				Repo string `json:"repo"`
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(entry, &obj); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: entry %d is neither a string nor an object", path, i)
+			}
+			name = obj.Repo
+			if name == "" {
+				name = obj.Name
+			}
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("failed to parse %s: entry %d has no repository name", path, i)
+		}
+		if !seen[name] {
+			seen[name] = true
+			repos = append(repos, name)
+		}
+	}
+
+	return repos, nil
+}
+
+// readRepoLines reads newline-separated repository names, skipping blank lines,
+// "#" comments and duplicates
+func readRepoLines(r io.Reader) ([]string, error) {
+	var repos []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		repos = append(repos, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// readReposFile reads newline-separated repository names from a file
+func readReposFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	repos, err := readRepoLines(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return repos, nil
+}
+
+// checkRepoSources ensures at most one way of listing target repositories is used
+func checkRepoSources() error {
+	var used []string
+	for _, name := range []string{"repo", "repos", "repos-file", "repos-from-json", "rerun-failed", "query-file", "depends-on", "repo-content-query", "affiliation", "repos-all-orgs", "numbers-from"} {
+		if viper.GetString(name) != "" {
+			used = append(used, "--"+name)
+		}
+	}
+	for _, name := range []string{"repos-from-stdin", "interactive-repos"} {
+		if viper.GetBool(name) {
+			used = append(used, "--"+name)
+		}
+	}
+	if viper.GetInt("project") != 0 {
+		used = append(used, "--project")
+	}
+	if len(used) > 1 {
+		return fmt.Errorf("%s cannot be used together", strings.Join(used, ", "))
+	}
+	return nil
+}
+
+// resolveRepos determines the target repositories from the repository selection flags
+func resolveRepos(ic *IssueCreator) ([]string, error) {
+	if err := checkRepoSources(); err != nil {
+		return nil, err
+	}
+
+	if repo := viper.GetString("repo"); repo != "" {
+		// Use the single repository without listing the organization
+		_, name, err := parseRepoRef(repo)
+		if err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	}
+	if repos := viper.GetString("repos"); repos != "" {
+		// Use provided repositories
+		return splitList(repos), nil
+	}
+	if reposFile := viper.GetString("repos-file"); reposFile != "" {
+		// Use repositories listed one per line in a file
+		return readReposFile(reposFile)
+	}
+	if reposFromJSON := viper.GetString("repos-from-json"); reposFromJSON != "" {
+		// Use repositories from a previous results file
+		return readReposFromJSON(reposFromJSON)
+	}
+	if viper.GetBool("repos-from-stdin") {
+		// Use repositories piped in one per line
+		repos, err := readRepoLines(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read repositories from stdin: %w", err)
+		}
+		return repos, nil
+	}
+	if rerunFailed := viper.GetString("rerun-failed"); rerunFailed != "" {
+		// Use the failed repositories of a previous run's CSV report
+		return readFailedFromCSV(rerunFailed)
+	}
+	if queryFile := viper.GetString("query-file"); queryFile != "" {
+		// Use the union of repository search results; the file has the same
+		// line format as --repos-file
+		queries, err := readReposFile(queryFile)
+		if err != nil {
+			return nil, err
+		}
+		return ic.SearchRepositories(queries)
+	}
+	if module := viper.GetString("depends-on"); module != "" {
+		// Use repositories whose dependency manifests mention the module
+		fmt.Fprintf(ic.out, "Searching repositories of %s that depend on %s...\n", ic.org, module)
+		return ic.DependentRepositories(module)
+	}
+	if query := viper.GetString("repo-content-query"); query != "" {
+		// Use repositories with file contents matching a code search
+		fmt.Fprintf(ic.out, "Searching code of %s: %s\n", ic.org, query)
+		return ic.CodeSearchRepositories(query)
+	}
+	if project := viper.GetInt("project"); project != 0 {
+		// Use repositories referenced by items on a project board
+		fmt.Fprintf(ic.out, "Fetching repositories from project %d of %s...\n", project, ic.org)
+		return ic.ProjectRepositories(project)
+	}
+
+	filters, err := repoFilters()
+	if err != nil {
+		return nil, err
+	}
+
+	if enterprise := viper.GetString("repos-all-orgs"); enterprise != "" {
+		// Use every repository of every organization in the enterprise as owner/name
+		fmt.Fprintf(ic.out, "Fetching organizations of enterprise %s...\n", enterprise)
+		ic.listConcurrency = viper.GetInt("list-concurrency")
+		return ic.EnterpriseRepositories(enterprise, filters)
+	}
+
+	if affiliation := viper.GetString("affiliation"); affiliation != "" {
+		// Use the repositories the token's user can access; without an organization
+		// they are returned as owner/name across all owners
+		fmt.Fprintf(ic.out, "Fetching repositories with affiliation %s...\n", affiliation)
+		all, err := ic.ListAffiliatedRepositories(affiliation)
+		if err != nil {
+			return nil, err
+		}
+		if viper.GetBool("preview-count-by-filter") {
+			printFilterFunnel(ic.out, all, filters)
+		}
+		var repoList []string
+		for _, repo := range applyRepoFilters(all, filters) {
+			switch {
+			case ic.org == "":
+				repoList = append(repoList, repo.GetFullName())
+			case strings.EqualFold(repo.GetOwner().GetLogin(), ic.org):
+				repoList = append(repoList, repo.GetName())
+			}
+		}
+		return repoList, nil
+	}
+
+	if numbersFrom := viper.GetString("numbers-from"); numbersFrom != "" {
+		// The issue number mapping is a selection of its own, so no other one is given
+		return numbersRepos(numbersFrom)
+	}
+
+	// Fetch all repositories
+	fmt.Fprintf(ic.out, "Fetching repositories from organization: %s...\n", ic.org)
+	ic.listConcurrency = viper.GetInt("list-concurrency")
+	all, err := ic.ListRepositories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repositories: %v", err)
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("organization %s has no repositories visible to this token; "+
+			"private repositories require a classic token with the repo scope or a fine-grained token with access to them", ic.org)
+	}
+
+	if viper.GetBool("preview-count-by-filter") {
+		printFilterFunnel(ic.out, all, filters)
+	}
+	var repoList []string
+	for _, repo := range applyRepoFilters(all, filters) {
+		repoList = append(repoList, repo.GetName())
+	}
+
+	if viper.GetBool("interactive-repos") && len(repoList) > 0 {
+		if !stdinIsTerminal() {
+			fmt.Fprintln(ic.out, "stdin is not a terminal; skipping interactive selection and using all repositories")
+			return repoList, nil
+		}
+		return pickRepos(repoList, os.Stdin, ic.out)
+	}
+	return repoList, nil
+}
+
+// ListAffiliatedRepositories lists the repositories of the token's user by affiliation,
+// a comma-separated combination of owner, collaborator and organization_member
+func (ic *IssueCreator) ListAffiliatedRepositories(affiliation string) ([]*github.Repository, error) {
+	for _, value := range splitList(affiliation) {
+		switch value {
+		case "owner", "collaborator", "organization_member":
+		default:
+			return nil, fmt.Errorf("invalid --affiliation %q: use owner, collaborator and/or organization_member", value)
+		}
+	}
+
+	opts := &github.RepositoryListByAuthenticatedUserOptions{
+		Affiliation: strings.Join(splitList(affiliation), ","),
+		ListOptions: ic.listOptions(),
+	}
+	var repos []*github.Repository
+	for {
+		page, resp, err := ic.repos.ListByAuthenticatedUser(ic.ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		repos = append(repos, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return repos, nil
+}
+
+// resolveOrgRepos resolves the target repositories of a command that works within one
+// organization. owner/name entries of that organization are reduced to their names and
+// entries of other owners are rejected, since only create handles several owners.
+func resolveOrgRepos(ic *IssueCreator) ([]string, error) {
+	repoList, err := resolveRepos(ic)
+	if err != nil {
+		return nil, err
+	}
+	for i, repo := range repoList {
+		if !strings.Contains(repo, "/") {
+			continue
+		}
+		owner, name, err := parseRepoRef(repo)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(owner, ic.org) {
+			return nil, fmt.Errorf("%s is not in organization %s; only create can target repositories of other owners", repo, ic.org)
+		}
+		repoList[i] = name
+	}
+	return repoList, nil
+}
+
+// qualifyRepos resolves a repository list that may mix bare names of org with owner/name
+// entries. When every entry belongs to org it returns the bare names; otherwise it returns
+// owner/name references for all entries and reports that several owners are involved.
+func qualifyRepos(org string, repos []string) ([]string, bool, error) {
+	refs := make([]string, 0, len(repos))
+	names := make([]string, 0, len(repos))
+	mixed := false
+	for _, repo := range repos {
+		if !strings.Contains(repo, "/") {
+			refs = append(refs, org+"/"+repo)
+			names = append(names, repo)
+			continue
+		}
+		owner, name, err := parseRepoRef(repo)
+		if err != nil {
+			return nil, false, err
+		}
+		if !strings.EqualFold(owner, org) {
+			mixed = true
+		}
+		refs = append(refs, owner+"/"+name)
+		names = append(names, name)
+	}
+	if mixed {
+		return refs, true, nil
+	}
+	return names, false, nil
+}
+
+// groupByOwner splits owner/name references into repository names per owner,
+// keeping the order in which owners first appear
+func groupByOwner(refs []string) ([]string, map[string][]string, error) {
+	var owners []string
+	byOwner := map[string][]string{}
+	for _, ref := range refs {
+		owner, name, err := parseRepoRef(ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		if byOwner[owner] == nil {
+			owners = append(owners, owner)
+		}
+		byOwner[owner] = append(byOwner[owner], name)
+	}
+	return owners, byOwner, nil
+}
+
+// SearchRepositories runs each repository search query within the organization and
+// returns the union of the results in the order they were found, without duplicates
+func (ic *IssueCreator) SearchRepositories(queries []string) ([]string, error) {
+	var repos []string
+	seen := map[string]bool{}
+	for _, query := range queries {
+		fmt.Fprintf(ic.out, "Searching repositories: %s\n", query)
+		opts := &github.SearchOptions{ListOptions: ic.listOptions()}
+		found := 0
+		for {
+			result, resp, err := ic.client.Search.Repositories(ic.ctx, fmt.Sprintf("org:%s %s", ic.org, query), opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search repositories for %q: %w", query, err)
+			}
+			for _, repo := range result.Repositories {
+				found++
+				if !seen[repo.GetName()] {
+					seen[repo.GetName()] = true
+					repos = append(repos, repo.GetName())
+				}
+			}
+			if resp.NextPage == 0 {
+				if result.GetTotal() > found {
+					fmt.Fprintf(ic.out, "Warning: %q matched %d repositories but the search API returns at most %d\n", query, result.GetTotal(), found)
+				}
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+	}
+	return repos, nil
+}
+
+// repoFilter narrows the repositories listed from an organization
+type repoFilter struct {
+	name string
+	keep func(repo *github.Repository) bool
+}
+
+// repoFilters builds the filters selected by flags; all of them must match for a repository to be kept
+func repoFilters() ([]repoFilter, error) {
+	var filters []repoFilter
+
+	if excluded := listFlag("exclude-topic"); len(excluded) > 0 {
+		filters = append(filters, repoFilter{
+			name: "exclude-topic",
+			keep: func(repo *github.Repository) bool {
+				for _, topic := range repo.Topics {
+					for _, e := range excluded {
+						if topic == e {
+							return false
+						}
+					}
+				}
+				return true
+			},
+		})
+	}
+
+	if branch := viper.GetString("default-branch"); branch != "" {
+		filters = append(filters, repoFilter{
+			name: "default-branch",
+			keep: func(repo *github.Repository) bool {
+				return repo.GetDefaultBranch() == branch
+			},
+		})
+	}
+
+	license := viper.GetString("license")
+	noLicense := viper.GetBool("no-license")
+	if license != "" && noLicense {
+		return nil, fmt.Errorf("--license and --no-license cannot be used together")
+	}
+	if license != "" {
+		filters = append(filters, repoFilter{
+			name: "license",
+			keep: func(repo *github.Repository) bool {
+				return strings.EqualFold(repo.GetLicense().GetSPDXID(), license)
+			},
+		})
+	}
+	if noLicense {
+		filters = append(filters, repoFilter{
+			name: "no-license",
+			keep: func(repo *github.Repository) bool {
+				return repo.GetLicense() == nil
+			},
+		})
+	}
+
+	if minOpen := viper.GetInt("min-open-issues"); minOpen > 0 {
+		// GitHub counts open pull requests as open issues here
+		filters = append(filters, repoFilter{
+			name: "min-open-issues",
+			keep: func(repo *github.Repository) bool {
+				return repo.GetOpenIssuesCount() >= minOpen
+			},
+		})
+	}
+
+	if pattern := viper.GetString("repo-regex"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --repo-regex %q: %v", pattern, err)
+		}
+		filters = append(filters, repoFilter{
+			name: "repo-regex",
+			keep: func(repo *github.Repository) bool {
+				return re.MatchString(repo.GetName())
+			},
+		})
+	}
+
+	// Exclusions run last so they carve out of whatever the inclusion filters selected
+	if pattern := viper.GetString("repos-exclude-regex"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --repos-exclude-regex %q: %v", pattern, err)
+		}
+		filters = append(filters, repoFilter{
+			name: "repos-exclude-regex",
+			keep: func(repo *github.Repository) bool {
+				return !re.MatchString(repo.GetName())
+			},
+		})
+	}
+
+	return filters, nil
+}
+
+// applyRepoFilters returns the repositories that match every filter
+func applyRepoFilters(repos []*github.Repository, filters []repoFilter) []*github.Repository {
+	for _, filter := range filters {
+		var kept []*github.Repository
+		for _, repo := range repos {
+			if filter.keep(repo) {
+				kept = append(kept, repo)
+			}
+		}
+		repos = kept
+	}
+	return repos
+}
+
+// printFilterFunnel shows how many repositories remain after each filter in the
+// order they are applied, to explain why fewer repositories are targeted than expected
+func printFilterFunnel(w io.Writer, repos []*github.Repository, filters []repoFilter) {
+	fmt.Fprintln(w, "Filter funnel:")
+	fmt.Fprintf(w, "  listed: %d\n", len(repos))
+	for _, filter := range filters {
+		kept := applyRepoFilters(repos, []repoFilter{filter})
+		fmt.Fprintf(w, "  after --%s: %d (-%d)\n", filter.name, len(kept), len(repos)-len(kept))
+		repos = kept
+	}
+	fmt.Fprintf(w, "  final: %d\n", len(repos))
+}
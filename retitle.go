@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var retitleCmd = &cobra.Command{
+	Use:   "retitle",
+	Short: "Rename open issues whose title matches a pattern",
+	Long: `Rename open issues whose title matches a pattern.
+Open issues whose title contains --title-match (or matches it as a regular expression with --regex)
+are renamed to --new-title in each repository. --new-title is a Go template with .Org, .Repo,
+.Number and .Title (the current title), so issue numbers may differ per repository.`,
+	RunE: runRetitle,
+}
+
+// retitleData is the data available to the --new-title template
+type retitleData struct {
+	Org    string
+	Repo   string
+	Number int
+	Title  string
+}
+
+// ListOpenIssues returns the open issues of a repository whose title matches, without pull requests
+func (ic *IssueCreator) ListOpenIssues(repo string, match func(title string) bool) ([]*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{State: "open", ListOptions: ic.listOptions()}
+
+	var issues []*github.Issue
+	for {
+		page, resp, err := ic.issues.ListByRepo(ic.ctx, ic.org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues in %s/%s: %w", ic.org, repo, err)
+		}
+		for _, issue := range page {
+			if !issue.IsPullRequest() && match(issue.GetTitle()) {
+				issues = append(issues, issue)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return issues, nil
+}
+
+// RetitleIssue changes the title of an issue
+func (ic *IssueCreator) RetitleIssue(repo string, number int, title string) error {
+	if _, _, err := ic.issues.Edit(ic.ctx, ic.org, repo, number, &github.IssueRequest{Title: &title}); err != nil {
+		return fmt.Errorf("failed to retitle %s/%s#%d: %w", ic.org, repo, number, err)
+	}
+	return nil
+}
+
+func runRetitle(cmd *cobra.Command, args []string) error {
+	org, err := resolveOrg()
+	if err != nil {
+		return err
+	}
+	titleMatch := viper.GetString("title-match")
+	newTitle := viper.GetString("new-title")
+	dryRun := viper.GetBool("dry-run")
+
+	if org == "" || titleMatch == "" || newTitle == "" {
+		return fmt.Errorf("missing required arguments: --org (or --repo), --title-match and --new-title are required")
+	}
+
+	match := func(title string) bool { return strings.Contains(title, titleMatch) }
+	if viper.GetBool("regex") {
+		re, err := regexp.Compile(titleMatch)
+		if err != nil {
+			return fmt.Errorf("invalid --title-match: %w", err)
+		}
+		match = re.MatchString
+	}
+	tmpl, err := parseTemplate("title", newTitle, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	repoList, err := resolveOrgRepos(creator)
+	if err != nil {
+		return err
+	}
+	if len(repoList) == 0 {
+		return fmt.Errorf("no repositories found")
+	}
+
+	renamed := 0
+	unchanged := 0
+	failed := 0
+	for _, repo := range repoList {
+		issues, err := creator.ListOpenIssues(repo, match)
+		if err != nil {
+			fmt.Fprintf(creator.out, "%s/%s: %s (%v)\n", org, repo, markFail(), err)
+			failed++
+			continue
+		}
+
+		for _, issue := range issues {
+			fmt.Fprintf(creator.out, "Retitling %s/%s#%d %q", org, repo, issue.GetNumber(), issue.GetTitle())
+			title, err := renderRetitle(tmpl, retitleData{Org: org, Repo: repo, Number: issue.GetNumber(), Title: issue.GetTitle()})
+			switch {
+			case err != nil:
+				fmt.Fprintf(creator.out, "... %s (%v)\n", markFail(), err)
+				failed++
+				continue
+			case title == issue.GetTitle():
+				fmt.Fprintln(creator.out, "... - (unchanged)")
+				unchanged++
+				continue
+			}
+
+			fmt.Fprintf(creator.out, " → %q... ", title)
+			if dryRun {
+				fmt.Fprintln(creator.out, markOK(), "(dry run)")
+				renamed++
+				continue
+			}
+			if err := creator.RetitleIssue(repo, issue.GetNumber(), title); err != nil {
+				fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+				failed++
+				continue
+			}
+			fmt.Fprintln(creator.out, markOK())
+			renamed++
+		}
+	}
+
+	fmt.Fprintln(creator.out, "---")
+	fmt.Fprintf(creator.out, "Summary: %d retitled, %d unchanged, %d failed\n", renamed, unchanged, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// renderRetitle renders the new title of an issue
+func renderRetitle(tmpl *template.Template, data retitleData) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render --new-title: %w", err)
+	}
+	title := strings.TrimSpace(b.String())
+	if title == "" {
+		return "", fmt.Errorf("--new-title renders an empty title")
+	}
+	return title, nil
+}
+
+func init() {
+	addRepoFlags(retitleCmd)
+	retitleCmd.Flags().String("title-match", "", "Text the titles of the issues to rename contain (required)")
+	retitleCmd.Flags().Bool("regex", false, "Treat --title-match as a regular expression")
+	retitleCmd.Flags().String("new-title", "", "New title; a Go template with .Org, .Repo, .Number and .Title (required)")
+	retitleCmd.Flags().Bool("dry-run", false, "Show the new titles without changing them")
+
+	rootCmd.AddCommand(retitleCmd)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// configFlagTypes maps every flag name of the command tree to the value types it
+// takes; a name shared by several commands may have more than one
+func configFlagTypes(root *cobra.Command) map[string]map[string]bool {
+	types := map[string]map[string]bool{}
+	add := func(f *pflag.Flag) {
+		if types[f.Name] == nil {
+			types[f.Name] = map[string]bool{}
+		}
+		types[f.Name][f.Value.Type()] = true
+	}
+
+	commands := []*cobra.Command{root}
+	for len(commands) > 0 {
+		cmd := commands[0]
+		commands = append(commands[1:], cmd.Commands()...)
+		cmd.Flags().VisitAll(add)
+		cmd.PersistentFlags().VisitAll(add)
+	}
+	return types
+}
+
+// configValueFits reports whether a YAML value can be used for a flag of the given type
+func configValueFits(value *yaml.Node, flagType string) bool {
+	switch flagType {
+	case "bool":
+		return value.Kind == yaml.ScalarNode && value.Tag == "!!bool"
+	case "int", "int64":
+		return value.Kind == yaml.ScalarNode && value.Tag == "!!int"
+	case "float64":
+		return value.Kind == yaml.ScalarNode && (value.Tag == "!!int" || value.Tag == "!!float")
+	case "duration":
+		_, err := time.ParseDuration(value.Value)
+		return value.Kind == yaml.ScalarNode && err == nil
+	case "stringArray", "stringSlice":
+		if value.Kind == yaml.SequenceNode {
+			for _, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					return false
+				}
+			}
+			return true
+		}
+		return value.Kind == yaml.ScalarNode
+	default:
+		return value.Kind == yaml.ScalarNode
+	}
+}
+
+// configTypeNames describes flag types in error messages
+var configTypeNames = map[string]string{
+	"bool":        "true or false",
+	"int":         "a whole number",
+	"int64":       "a whole number",
+	"float64":     "a number",
+	"duration":    "a duration such as 30s or 2m",
+	"stringArray": "a string or a list of strings",
+	"stringSlice": "a string or a list of strings",
+	"string":      "a string",
+}
+
+// checkConfigMapping reports unknown keys and mistyped values of one block of the config file
+func checkConfigMapping(mapping *yaml.Node, prefix string, types map[string]map[string]bool, problems *[]string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+
+		if key.Value == "profiles" && prefix == "" {
+			if value.Kind != yaml.MappingNode {
+				*problems = append(*problems, fmt.Sprintf("line %d: profiles must map profile names to settings", key.Line))
+				continue
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name, profile := value.Content[j], value.Content[j+1]
+				if profile.Kind != yaml.MappingNode {
+					*problems = append(*problems, fmt.Sprintf("line %d: profile %q must hold settings", name.Line, name.Value))
+					continue
+				}
+				checkConfigMapping(profile, "profiles."+name.Value+".", types, problems)
+			}
+			continue
+		}
+
+		accepted, ok := types[key.Value]
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("line %d: unknown key %q", key.Line, prefix+key.Value))
+			continue
+		}
+		var wants []string
+		fits := false
+		for flagType := range accepted {
+			fits = fits || configValueFits(value, flagType)
+			wants = append(wants, configTypeNames[flagType])
+		}
+		if !fits {
+			sort.Strings(wants)
+			*problems = append(*problems, fmt.Sprintf("line %d: %s must be %s", value.Line, prefix+key.Value, strings.Join(wants, " or ")))
+		}
+	}
+}
+
+// validateConfig checks the config file in use, if any, against the flags of the command
+// tree, so that misspelled keys and wrong value types are reported instead of ignored.
+// Only YAML and JSON files are checked; other formats viper reads are used as they are.
+func validateConfig(root *cobra.Command, path string) error {
+	problems, err := configProblems(root, path)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config file %s:\n  %s", path, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// configProblems returns the unknown keys and mistyped values of the config file at path.
+// The error is set when the file cannot be read or parsed at all.
+func configProblems(root *cobra.Command, path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid config file %s: expected a mapping of flag names to values", path)
+	}
+
+	var problems []string
+	checkConfigMapping(doc.Content[0], "", configFlagTypes(root), &problems)
+	return problems, nil
+}
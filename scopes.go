@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// impliedScopes lists the OAuth scopes that are granted by a broader scope
+var impliedScopes = map[string][]string{
+	"repo":      {"public_repo"},
+	"admin:org": {"write:org", "read:org"},
+	"write:org": {"read:org"},
+}
+
+// ScopeError reports OAuth scopes the token needs but was not granted
+type ScopeError struct {
+	Missing []string
+	Granted []string
+}
+
+func (e *ScopeError) Error() string {
+	granted := strings.Join(e.Granted, ", ")
+	if granted == "" {
+		granted = "none"
+	}
+	return fmt.Sprintf("token is missing required OAuth scopes: %s (granted: %s)", strings.Join(e.Missing, ", "), granted)
+}
+
+// hasScope reports whether want is granted directly or through a broader scope
+func hasScope(granted []string, want string) bool {
+	for _, scope := range granted {
+		if scope == want || slices.Contains(impliedScopes[scope], want) {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenScopes returns the OAuth scopes granted to the token from the X-OAuth-Scopes
+// header. ok is false for tokens that do not report scopes, such as fine-grained
+// personal access tokens and GitHub App tokens.
+func (ic *IssueCreator) TokenScopes() (scopes []string, ok bool, err error) {
+	_, resp, err := ic.client.Users.Get(ic.ctx, "")
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to validate token: %w", err)
+	}
+	values, ok := resp.Header["X-Oauth-Scopes"]
+	if !ok {
+		return nil, false, nil
+	}
+	return splitList(strings.Join(values, ",")), true, nil
+}
+
+// CheckScopes verifies that the token has the scopes to create issues: repo, or at
+// least public_repo, and read:org when teams are mentioned. Having only public_repo
+// yields a warning, since issues in private repositories will then fail.
+func (ic *IssueCreator) CheckScopes() (warnings []string, err error) {
+	granted, ok, err := ic.TokenScopes()
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var missing []string
+	if !hasScope(granted, "public_repo") {
+		missing = append(missing, "repo")
+	} else if !hasScope(granted, "repo") {
+		warnings = append(warnings, "token has public_repo but not repo scope; issues in private repositories will fail")
+	}
+	if len(ic.teamAssignees) > 0 && !hasScope(granted, "read:org") {
+		missing = append(missing, "read:org")
+	}
+	if len(missing) > 0 {
+		return warnings, &ScopeError{Missing: missing, Granted: granted}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// IssuesAPI is the part of the GitHub issues service used by IssueCreator.
+// It is satisfied by *github.IssuesService and lets tests inject a fake.
+type IssuesAPI interface {
+	Create(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	Get(ctx context.Context, owner, repo string, number int) (*github.Issue, *github.Response, error)
+	Edit(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	Lock(ctx context.Context, owner, repo string, number int, opts *github.LockIssueOptions) (*github.Response, error)
+	ListByRepo(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	CreateComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	IsAssignee(ctx context.Context, owner, repo, user string) (bool, *github.Response, error)
+	ListMilestones(ctx context.Context, owner, repo string, opts *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error)
+	CreateMilestone(ctx context.Context, owner, repo string, milestone *github.Milestone) (*github.Milestone, *github.Response, error)
+	RemoveMilestone(ctx context.Context, owner, repo string, number int) (*github.Issue, *github.Response, error)
+
+	GetLabel(ctx context.Context, owner, repo, name string) (*github.Label, *github.Response, error)
+	ListLabels(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Label, *github.Response, error)
+	CreateLabel(ctx context.Context, owner, repo string, label *github.Label) (*github.Label, *github.Response, error)
+	EditLabel(ctx context.Context, owner, repo, name string, label *github.Label) (*github.Label, *github.Response, error)
+	DeleteLabel(ctx context.Context, owner, repo, name string) (*github.Response, error)
+	AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error)
+	ReplaceLabelsForIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error)
+}
+
+// RepositoriesAPI is the part of the GitHub repositories service used by IssueCreator.
+// It is satisfied by *github.RepositoriesService and lets tests inject a fake.
+type RepositoriesAPI interface {
+	Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
+	GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	ListByOrg(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error)
+	ListByAuthenticatedUser(ctx context.Context, opts *github.RepositoryListByAuthenticatedUserOptions) ([]*github.Repository, *github.Response, error)
+}
+
+var (
+	_ IssuesAPI       = (*github.IssuesService)(nil)
+	_ RepositoriesAPI = (*github.RepositoriesService)(nil)
+)
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// SSOError reports a token that is not authorized for an organization's SAML single sign-on
+type SSOError struct {
+	Org string
+
+	// URL is where the token can be authorized; it may be empty
+	URL string
+}
+
+func (e *SSOError) Error() string {
+	msg := fmt.Sprintf("the token is not authorized for SAML single sign-on in organization %s", e.Org)
+	if e.URL != "" {
+		msg += "; authorize it at " + e.URL
+	}
+	return msg
+}
+
+// ssoURL returns the authorization URL from an "X-GitHub-SSO: required; url=..." header
+func ssoURL(resp *github.Response) (string, bool) {
+	if resp == nil {
+		return "", false
+	}
+	header := resp.Header.Get("X-GitHub-SSO")
+	if !strings.HasPrefix(header, "required") {
+		return "", false
+	}
+	for _, part := range strings.Split(header, ";") {
+		if url, ok := strings.CutPrefix(strings.TrimSpace(part), "url="); ok {
+			return url, true
+		}
+	}
+	return "", true
+}
+
+// CheckOrgAccess verifies that the token's user is a member of the organization and that
+// the token is authorized for its single sign-on. A missing membership is only a warning,
+// since outside collaborators can still create issues; an SSO block fails with an SSOError.
+// Tokens that cannot query memberships, such as GitHub App tokens, are not checked.
+func (ic *IssueCreator) CheckOrgAccess() (warnings []string, err error) {
+	_, resp, err := ic.client.Organizations.GetOrgMembership(ic.ctx, "", ic.org)
+	if err == nil {
+		return nil, nil
+	}
+	if url, ok := ssoURL(resp); ok {
+		return nil, &SSOError{Org: ic.org, URL: url}
+	}
+
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+		return []string{fmt.Sprintf("the token's user is not a member of %s; only repositories it was given access to will work", ic.org)}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report how many campaign issues are still open",
+	Long: `Report how many campaign issues are still open.
+Issues are found across the organization by label and/or a marker text in the body,
+and open and closed counts are printed per repository with a completion percentage.`,
+	RunE: runStatus,
+}
+
+// repoStatus counts open and closed issues in one repository
+type repoStatus struct {
+	open   int
+	closed int
+}
+
+// campaignQuery builds the issue search query for a label and/or body marker in an organization.
+// Without excludePRs pull requests carrying the label or marker match as well.
+func campaignQuery(org, label, marker string, excludePRs bool) string {
+	query := fmt.Sprintf("org:%s", org)
+	if excludePRs {
+		query += " is:issue"
+	}
+	if label != "" {
+		query += fmt.Sprintf(" label:%q", label)
+	}
+	if marker != "" {
+		query += fmt.Sprintf(" %q in:body", marker)
+	}
+	return query
+}
+
+// SearchIssues returns all issues matching a search query along with the total match count.
+// The search API returns at most 1000 results, so the slice may be shorter than the total.
+func (ic *IssueCreator) SearchIssues(query string) ([]*github.Issue, int, error) {
+	opts := &github.SearchOptions{ListOptions: ic.listOptions()}
+
+	var issues []*github.Issue
+	total := 0
+	for {
+		result, resp, err := ic.client.Search.Issues(ic.ctx, query, opts)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to search issues: %w", err)
+		}
+
+		total = result.GetTotal()
+		issues = append(issues, result.Issues...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return issues, total, nil
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	org := viper.GetString("org")
+	label := viper.GetString("label")
+	marker := viper.GetString("marker")
+	excludePRs := viper.GetBool("exclude-prs")
+
+	if org == "" || (label == "" && marker == "") {
+		return fmt.Errorf("missing required arguments: --org and one of --label or --marker are required")
+	}
+
+	token, err := resolveToken()
+	if err != nil {
+		return err
+	}
+	creator, err := NewIssueCreator(token, org, "", "", nil, clientOptionsFromFlags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %v", err)
+	}
+
+	query := campaignQuery(org, label, marker, excludePRs)
+	fmt.Fprintf(creator.out, "Searching: %s\n", query)
+	issues, total, err := creator.SearchIssues(query)
+	if err != nil {
+		return err
+	}
+
+	byRepo := map[string]*repoStatus{}
+	open, closed := 0, 0
+	for _, issue := range issues {
+		// The search qualifier already leaves pull requests out; this guards against items slipping through
+		if excludePRs && issue.IsPullRequest() {
+			continue
+		}
+		repo := path.Base(issue.GetRepositoryURL())
+		if byRepo[repo] == nil {
+			byRepo[repo] = &repoStatus{}
+		}
+		if issue.GetState() == "closed" {
+			byRepo[repo].closed++
+			closed++
+		} else {
+			byRepo[repo].open++
+			open++
+		}
+	}
+
+	repos := make([]string, 0, len(byRepo))
+	for repo := range byRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	fmt.Fprintln(creator.out, "---")
+	for _, repo := range repos {
+		fmt.Fprintf(creator.out, "%s/%s: %d open, %d closed\n", org, repo, byRepo[repo].open, byRepo[repo].closed)
+	}
+	fmt.Fprintln(creator.out, "---")
+
+	if open+closed == 0 {
+		fmt.Fprintln(creator.out, "No matching issues found")
+		return nil
+	}
+	fmt.Fprintf(creator.out, "Summary: %d open, %d closed, %.1f%% complete\n", open, closed, 100*float64(closed)/float64(open+closed))
+	if total > len(issues) {
+		fmt.Fprintf(creator.out, "Note: only %d of %d matching issues could be fetched from the search API\n", len(issues), total)
+	}
+
+	return nil
+}
+
+func init() {
+	statusCmd.Flags().StringP("org", "o", "", "GitHub organization name (required)")
+	statusCmd.Flags().String("label", "", "Label that marks the campaign issues")
+	statusCmd.Flags().String("marker", "", "Text that marks the campaign issues in their body")
+	statusCmd.Flags().Bool("exclude-prs", true, "Leave out pull requests carrying the label or marker; use --exclude-prs=false to count them too")
+
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// lookupUser checks that a user exists, once per run. A user that is not found stays
+// unknown for the rest of the run; other errors are not cached and checked again.
+func (ic *IssueCreator) lookupUser(user string) error {
+	ic.usersMu.Lock()
+	err, cached := ic.users[user]
+	ic.usersMu.Unlock()
+	if cached {
+		return err
+	}
+
+	_, resp, err := ic.client.Users.Get(ic.ctx, user)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return err
+	}
+
+	ic.usersMu.Lock()
+	if ic.users == nil {
+		ic.users = map[string]error{}
+	}
+	ic.users[user] = err
+	ic.usersMu.Unlock()
+	return err
+}
+
+// SubscribeUsers makes users follow an issue. GitHub has no API to subscribe other
+// users, but mentioning a user subscribes them, so existing users are mentioned in a
+// comment. The result of each user is written to w; unknown users are returned as failed.
+func (ic *IssueCreator) SubscribeUsers(w io.Writer, repo string, issue *github.Issue, users []string) (failed []string, err error) {
+	var mentions []string
+	for _, user := range users {
+		if err := ic.lookupUser(user); err != nil {
+			fmt.Fprintf(w, "    subscribe @%s... %s (%v)\n", user, markFail(), err)
+			failed = append(failed, user)
+			continue
+		}
+		mentions = append(mentions, "@"+user)
+	}
+	if len(mentions) == 0 {
+		return failed, nil
+	}
+
+	body := "cc " + strings.Join(mentions, " ")
+	if err := ic.CommentOnIssue(repo, issue.GetNumber(), body); err != nil {
+		return failed, err
+	}
+	for _, mention := range mentions {
+		fmt.Fprintf(w, "    subscribe %s... %s\n", mention, markOK())
+	}
+	return failed, nil
+}
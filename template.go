@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// templateData is the data available to title and description templates
+type templateData struct {
+	Org  string
+	Repo string
+
+	// Date is the start of the run formatted with --date-format in --timezone
+	Date string
+
+	// Language and Topics come from the repository's details, fetched only when a template uses them
+	Language string
+	Topics   []string
+}
+
+// templateFuncs are the helper functions available in templates. The now function formats
+// the start of the run with a layout, so every issue of a campaign shows the same time.
+// Functions taking the value last work in pipelines, e.g. {{.Repo | replace "-" " " | title}}.
+func templateFuncs(start time.Time) template.FuncMap {
+	return template.FuncMap{
+		"now":   func(layout string) string { return start.Format(layout) },
+		"date":  formatDate,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"title": titleCase,
+		"trim":  strings.TrimSpace,
+		"replace": func(old, repl, s string) string {
+			return strings.ReplaceAll(s, old, repl)
+		},
+		"split": func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+		"default": func(fallback, s string) string {
+			if s == "" {
+				return fallback
+			}
+			return s
+		},
+	}
+}
+
+// titleCase upper-cases the first letter of every word
+func titleCase(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(prev) {
+			r = unicode.ToUpper(r)
+		}
+		prev = r
+		return r
+	}, s)
+}
+
+// formatDate reformats a date given as 2006-01-02 or RFC 3339 with a layout
+func formatDate(layout, value string) (string, error) {
+	for _, in := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(in, value); err == nil {
+			return t.Format(layout), nil
+		}
+	}
+	return "", fmt.Errorf("date: %q is neither 2006-01-02 nor RFC 3339", value)
+}
+
+// parseTemplate parses a title or description as a Go template with templateFuncs
+func parseTemplate(name, text string, start time.Time) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs(start)).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// execute renders a template for a repository
+func (ic *IssueCreator) execute(tmpl *template.Template, repo string) (string, error) {
+	var b strings.Builder
+	data := templateData{Org: ic.org, Repo: repo, Date: ic.runStart.Format(ic.dateFormat)}
+	if ic.fetchRepoInfo {
+		info, err := ic.repositoryInfo(repo)
+		if err != nil {
+			return "", err
+		}
+		data.Language = info.GetLanguage()
+		data.Topics = info.Topics
+	}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template for %s: %w", tmpl.Name(), repo, err)
+	}
+	return b.String(), nil
+}
+
+// usesRepoInfo reports whether any of the template texts refers to a field that
+// needs the repository's details
+func usesRepoInfo(texts []string) bool {
+	for _, text := range texts {
+		if strings.Contains(text, ".Language") || strings.Contains(text, ".Topics") {
+			return true
+		}
+	}
+	return false
+}
+
+// repositoryInfo returns the details of a repository, fetching them once per run
+func (ic *IssueCreator) repositoryInfo(repo string) (*github.Repository, error) {
+	ic.repoInfoMu.Lock()
+	info, ok := ic.repoInfo[repo]
+	ic.repoInfoMu.Unlock()
+	if ok {
+		return info, nil
+	}
+
+	// The lock is not held during the request so workers fetch in parallel
+	info, _, err := ic.repos.Get(ic.ctx, ic.org, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s for templates: %w", ic.org, repo, err)
+	}
+
+	ic.repoInfoMu.Lock()
+	defer ic.repoInfoMu.Unlock()
+	if ic.repoInfo == nil {
+		ic.repoInfo = map[string]*github.Repository{}
+	}
+	ic.repoInfo[repo] = info
+	return info, nil
+}
+
+// splitLabelTemplates separates the label entries containing "{{" and parses them as templates
+func splitLabelTemplates(labels []string, start time.Time) ([]string, []*template.Template, error) {
+	var static []string
+	var templates []*template.Template
+	for _, label := range labels {
+		if !strings.Contains(label, "{{") {
+			static = append(static, label)
+			continue
+		}
+		tmpl, err := parseTemplate("label", label, start)
+		if err != nil {
+			return nil, nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return static, templates, nil
+}
+
+// renderLabels returns the labels of the issue in a repository: the plain labels followed
+// by the rendered label templates, leaving out those that render empty
+func (ic *IssueCreator) renderLabels(repo string) ([]string, error) {
+	if len(ic.labelTemplates) == 0 {
+		return ic.labels, nil
+	}
+	var rendered []string
+	for _, tmpl := range ic.labelTemplates {
+		label, err := ic.execute(tmpl, repo)
+		if err != nil {
+			return nil, err
+		}
+		if label = strings.TrimSpace(label); label != "" {
+			rendered = append(rendered, label)
+		}
+	}
+	return mergeLists(ic.labels, rendered), nil
+}
+
+// renderTitle returns the issue title for a repository, rendering the title
+// template first when templating is enabled
+func (ic *IssueCreator) renderTitle(repo string) (string, error) {
+	if ic.titleTemplate == nil {
+		return ic.issueTitle(), nil
+	}
+	title, err := ic.execute(ic.titleTemplate, repo)
+	if err != nil {
+		return "", err
+	}
+	return ic.wrapTitle(strings.TrimSpace(title)), nil
+}
+
+// renderBody returns the issue body for a repository, rendering the description
+// template first when templating is enabled
+func (ic *IssueCreator) renderBody(repo string) (string, error) {
+	if ic.bodyTemplate == nil {
+		return ic.issueBody(), nil
+	}
+	body, err := ic.execute(ic.bodyTemplate, repo)
+	if err != nil {
+		return "", err
+	}
+	return ic.bodyWithExtras(body), nil
+}
+
+// checkTitles renders the title for every repository so that template errors
+// abort the run before any issue is created
+func (ic *IssueCreator) checkTitles(repos []string) error {
+	if ic.titleTemplate == nil {
+		return nil
+	}
+	for _, repo := range repos {
+		title, err := ic.renderTitle(repo)
+		if err != nil {
+			return err
+		}
+		if title == "" {
+			return fmt.Errorf("title template renders an empty title for %s", repo)
+		}
+		if err := checkTitleLength(title); err != nil {
+			return fmt.Errorf("title template for %s: %w", repo, err)
+		}
+	}
+	return nil
+}
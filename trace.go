@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// tracingTransport logs every HTTP request and response passing through it
+type tracingTransport struct {
+	base http.RoundTripper
+	out  io.Writer
+}
+
+// RoundTrip logs the request with its headers, the Authorization header redacted,
+// and the response status, rate-limit headers and duration
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fmt.Fprintf(t.out, "--> %s %s\n", req.Method, req.URL)
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := req.Header.Get(name)
+		if name == "Authorization" {
+			value = "[REDACTED]"
+		}
+		fmt.Fprintf(t.out, "    %s: %s\n", name, value)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(t.out, "<-- error after %s: %v\n", elapsed, err)
+		return resp, err
+	}
+
+	fmt.Fprintf(t.out, "<-- %s (%s)", resp.Status, elapsed)
+	if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+		fmt.Fprintf(t.out, " rate %s/%s, resource %s, reset %s",
+			remaining, resp.Header.Get("X-RateLimit-Limit"),
+			resp.Header.Get("X-RateLimit-Resource"), resp.Header.Get("X-RateLimit-Reset"))
+	}
+	fmt.Fprintln(t.out)
+	return resp, nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// checklistItem matches a task list line of a tracking issue, checked or not
+var checklistItem = regexp.MustCompile(`^\s*[-*] \[[ xX]\] (\S+)`)
+
+// checklistLine is the task list line a tracking issue holds for a repository
+func checklistLine(org, repo string) string {
+	return fmt.Sprintf("- [ ] %s/%s", org, repo)
+}
+
+// checklistRepos returns the owner/name references already listed in a tracking issue body
+func checklistRepos(body string) map[string]bool {
+	listed := map[string]bool{}
+	for _, line := range strings.Split(body, "\n") {
+		if m := checklistItem.FindStringSubmatch(line); m != nil {
+			listed[strings.ToLower(m[1])] = true
+		}
+	}
+	return listed
+}
+
+// mergeChecklist appends a line for each repository not yet listed in body. Lines
+// that would push the body past GitHub's limit are not added and returned as overflow.
+func mergeChecklist(body, org string, repos []string) (merged string, added, overflow []string) {
+	listed := checklistRepos(body)
+	merged = strings.TrimRight(body, "\n")
+	length := utf8.RuneCountInString(merged)
+	for _, repo := range repos {
+		ref := org + "/" + repo
+		if listed[strings.ToLower(ref)] {
+			continue
+		}
+		listed[strings.ToLower(ref)] = true
+
+		line := "\n" + checklistLine(org, repo)
+		if n := utf8.RuneCountInString(line); length+n <= maxBodyLength {
+			merged += line
+			length += n
+			added = append(added, repo)
+		} else {
+			overflow = append(overflow, repo)
+		}
+	}
+	return merged + "\n", added, overflow
+}
+
+// AppendToTrackingIssue adds the repositories missing from the checklist of an existing
+// tracking issue, leaving lines that are already present, checked or not, untouched.
+// The issue is only edited when there is something to add.
+func (ic *IssueCreator) AppendToTrackingIssue(trackingRepo string, number int, repos []string) (*github.Issue, []string, []string, error) {
+	issue, _, err := ic.issues.Get(ic.ctx, ic.org, trackingRepo, number)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get tracking issue %s/%s#%d: %w", ic.org, trackingRepo, number, err)
+	}
+
+	body, added, overflow := mergeChecklist(issue.GetBody(), ic.org, repos)
+	if len(added) == 0 || ic.dryRun {
+		return issue, added, overflow, nil
+	}
+
+	issue, _, err = ic.issues.Edit(ic.ctx, ic.org, trackingRepo, number, &github.IssueRequest{Body: &body})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to update tracking issue %s/%s#%d: %w", ic.org, trackingRepo, number, err)
+	}
+	return issue, added, overflow, nil
+}
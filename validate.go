@@ -0,0 +1,373 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check a campaign config or manifest without creating anything",
+	Long: `Check a campaign config or manifest without creating anything.
+The settings create would use are read from the config file, the selected profile,
+GITISSUEHELPER_* environment variables and --from-manifest. Unknown keys, missing required
+settings and conflicting settings are reported, the target repositories are resolved and
+counted against --max-repos, and each one is checked for the labels, assignees and milestone.
+All problems are listed at once and the exit code is nonzero when there are any. Only read
+requests are made.`,
+	RunE: runValidate,
+}
+
+// createRequirements lists create settings that only take effect together with one of others
+var createRequirements = []struct {
+	name     string
+	requires []string
+}{
+	{"tracking-issue", []string{"tracking-repo"}},
+	{"simulate", []string{"dry-run"}},
+	{"requests-out", []string{"dry-run"}},
+	{"validate", []string{"dry-run"}},
+	{"comment-on-existing", []string{"skip-duplicates"}},
+	{"lock-reason", []string{"create-locked", "announce"}},
+	{"pin", []string{"announce"}},
+	{"label-colors", []string{"create-labels"}},
+	{"label-descriptions", []string{"create-labels"}},
+	{"title-from-first-line", []string{"description-file", "description-url"}},
+}
+
+// settingGiven reports whether a setting has a value other than false or zero
+func settingGiven(name string) bool {
+	if !viper.IsSet(name) {
+		return false
+	}
+	switch viper.GetString(name) {
+	case "false", "0":
+		return false
+	}
+	return true
+}
+
+// durationSetting returns a duration setting, or fallback when it is unset. validate does
+// not register the create flags, so their defaults are not bound.
+func durationSetting(name string, fallback time.Duration) time.Duration {
+	if !viper.IsSet(name) {
+		return fallback
+	}
+	return viper.GetDuration(name)
+}
+
+// stringSetting returns a string setting, or fallback when it is unset
+func stringSetting(name, fallback string) string {
+	if !viper.IsSet(name) {
+		return fallback
+	}
+	return viper.GetString(name)
+}
+
+// settingList formats setting names as flags joined with "or"
+func settingList(names []string) string {
+	flags := make([]string, len(names))
+	for i, name := range names {
+		flags[i] = "--" + name
+	}
+	return strings.Join(flags, " or ")
+}
+
+// createSettings is the issue content of a create run, resolved from its settings
+type createSettings struct {
+	org, title, desc, milestone string
+	labels, assignees           []string
+	manifest                    *Manifest
+}
+
+// checkCreateSettings resolves the issue content of a create run and checks its settings
+// for missing, conflicting and invalid values. create stops at the first problem while
+// validate lists them all, so every check made before any request is sent lives here.
+func checkCreateSettings(org string) (settings createSettings, problems []error) {
+	settings = createSettings{
+		org:       org,
+		title:     viper.GetString("title"),
+		desc:      viper.GetString("description"),
+		milestone: viper.GetString("milestone"),
+		labels:    listFlag("labels"),
+		assignees: listFlag("assignees"),
+	}
+
+	// A manifest replaces the content and repository settings of the run it was written from
+	if manifestPath := viper.GetString("from-manifest"); manifestPath != "" {
+		for _, name := range manifestConflicts {
+			if settingGiven(name) {
+				problems = append(problems, fmt.Errorf("--%s cannot be combined with --from-manifest", name))
+			}
+		}
+		m, err := readManifest(manifestPath)
+		if err != nil {
+			problems = append(problems, err)
+		} else {
+			if org != "" && org != m.Org {
+				problems = append(problems, fmt.Errorf("--org %s conflicts with the manifest organization %s", org, m.Org))
+			}
+			settings.manifest = &m
+			settings.org, settings.title, settings.desc, settings.milestone = m.Org, m.Title, m.Body, m.Milestone
+			settings.labels = mergeLists(nil, m.Labels)
+			settings.assignees = mergeLists(nil, m.Assignees)
+		}
+	}
+
+	// A base64 body avoids escaping newlines in CI; GITISSUEHELPER_DESCRIPTION
+	// may also hold a multiline value directly
+	if encoded := viper.GetString("description-base64"); encoded != "" {
+		if settings.desc != "" {
+			problems = append(problems, fmt.Errorf("--description and --description-base64 cannot be used together"))
+		}
+		decoded, err := decodeBase64Description(encoded)
+		if err != nil {
+			problems = append(problems, err)
+		}
+		settings.desc = decoded
+	}
+
+	// Read the body and its front-matter; explicit settings win over front-matter
+	// values while labels and assignees are merged
+	descFile := viper.GetString("description-file")
+	descURL := viper.GetString("description-url")
+	if (settings.desc != "" && descFile != "") || (settings.desc != "" && descURL != "") || (descFile != "" && descURL != "") {
+		problems = append(problems, fmt.Errorf("only one of --description (or --description-base64), --description-file and --description-url can be used"))
+	} else if descFile != "" || descURL != "" {
+		var meta frontMatter
+		var body string
+		var err error
+		if descFile != "" {
+			meta, body, err = readDescriptionFile(descFile)
+		} else {
+			meta, body, err = fetchDescriptionURL(descURL, durationSetting("description-url-timeout", defaultDescriptionURLTimeout))
+		}
+		if err != nil {
+			problems = append(problems, err)
+		}
+		settings.desc = body
+		if viper.GetBool("title-from-first-line") && err == nil {
+			if settings.title != "" || meta.Title != "" {
+				problems = append(problems, fmt.Errorf("--title-from-first-line cannot be used together with --title or a front-matter title"))
+			} else if settings.title, settings.desc, err = splitTitleLine(body); err != nil {
+				problems = append(problems, err)
+			}
+		}
+		if settings.title == "" {
+			settings.title = meta.Title
+		}
+		if settings.milestone == "" {
+			settings.milestone = meta.Milestone
+		}
+		settings.labels = mergeLists(settings.labels, meta.Labels)
+		settings.assignees = mergeLists(settings.assignees, meta.Assignees)
+	}
+
+	// An empty description falls back to the configured default body
+	if strings.TrimSpace(settings.desc) == "" {
+		settings.desc = viper.GetString("default-body")
+	}
+
+	org = settings.org
+	enterprise := viper.GetString("repos-all-orgs")
+	if org == "" && viper.GetString("affiliation") == "" && enterprise == "" {
+		problems = append(problems, fmt.Errorf("missing --org (or --repo, --affiliation or --repos-all-orgs)"))
+	}
+	if settings.title == "" {
+		problems = append(problems, fmt.Errorf("missing --title"))
+	}
+	if strings.TrimSpace(settings.desc) == "" {
+		problems = append(problems, fmt.Errorf("missing --description (or --description-file/--description-url or --default-body)"))
+	}
+	if enterprise != "" && org != "" {
+		problems = append(problems, fmt.Errorf("--repos-all-orgs cannot be used together with --org"))
+	}
+	if org == "" {
+		// Without an organization --affiliation and --repos-all-orgs target repositories of several owners
+		for _, name := range singleOwnerFlags {
+			if viper.GetString(name) != "" {
+				problems = append(problems, fmt.Errorf("--%s requires --org when used with --affiliation or --repos-all-orgs", name))
+			}
+		}
+	}
+	for _, req := range createRequirements {
+		if !settingGiven(req.name) {
+			continue
+		}
+		found := false
+		for _, name := range req.requires {
+			found = found || settingGiven(name)
+		}
+		if !found {
+			problems = append(problems, fmt.Errorf("--%s can only be used together with %s", req.name, settingList(req.requires)))
+		}
+	}
+	if err := checkRepoSources(); err != nil {
+		problems = append(problems, err)
+	}
+
+	if simulate := viper.GetFloat64("simulate"); simulate < 0 || simulate > 100 {
+		problems = append(problems, fmt.Errorf("--simulate must be a percentage between 0 and 100"))
+	}
+	if durationSetting("delay-between", 0) < 0 {
+		problems = append(problems, fmt.Errorf("--delay-between must not be negative"))
+	}
+	switch viper.GetString("lock-reason") {
+	case "", "off-topic", "too heated", "resolved", "spam":
+	default:
+		problems = append(problems, fmt.Errorf("--lock-reason must be one of off-topic, too heated, resolved or spam"))
+	}
+
+	prefix := strings.TrimSpace(viper.GetString("title-prefix"))
+	suffix := strings.TrimSpace(viper.GetString("title-suffix"))
+	if viper.GetBool("template") {
+		if _, err := time.LoadLocation(stringSetting("timezone", "UTC")); err != nil {
+			problems = append(problems, fmt.Errorf("invalid --timezone: %w", err))
+		}
+		if _, err := parseTemplate("title", settings.title, time.Now()); err != nil {
+			problems = append(problems, err)
+		}
+		if _, err := parseTemplate("description", settings.desc, time.Now()); err != nil {
+			problems = append(problems, err)
+		}
+	} else if settings.title != "" {
+		// Templated titles are checked once rendered for each repository
+		if err := checkTitleLength(joinTitle(prefix, settings.title, suffix)); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if err := validateAttachments(splitList(viper.GetString("attachments"))); err != nil {
+		problems = append(problems, err)
+	}
+	return settings, problems
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	// The config file is checked here instead of before the command, so its problems
+	// are listed together with the others
+	var problems []string
+	var out io.Writer = os.Stdout
+	if path := viper.ConfigFileUsed(); path != "" {
+		fmt.Fprintf(out, "Checking config file %s\n", path)
+		configIssues, err := configProblems(cmd.Root(), path)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, configIssues...)
+	}
+
+	org, err := resolveOrg()
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	settings, settingProblems := checkCreateSettings(org)
+	for _, err := range settingProblems {
+		problems = append(problems, err.Error())
+	}
+	org, manifest := settings.org, settings.manifest
+
+	// Repositories can only be resolved and checked once the settings make sense
+	var creator *IssueCreator
+	if len(problems) == 0 {
+		token, err := resolveToken()
+		if err == nil {
+			creator, err = NewIssueCreator(token, org, settings.title, settings.desc, settings.labels, clientOptionsFromFlags())
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if creator != nil {
+		out = creator.out
+		creator.assignees = settings.assignees
+		creator.milestone = settings.milestone
+		if viper.GetBool("template") {
+			// Label templates are rendered per repository, so their labels are checked too
+			creator.runStart = time.Now()
+			creator.dateFormat = stringSetting("date-format", time.RFC3339)
+			creator.fetchRepoInfo = usesRepoInfo(creator.labels)
+			creator.labels, creator.labelTemplates, err = splitLabelTemplates(creator.labels, creator.runStart)
+			if err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+		if viper.GetBool("create-labels") {
+			if err := creator.setupCreateLabels(); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+
+		var repoList []string
+		if manifest != nil {
+			repoList = manifest.Repos
+		} else if repoList, err = resolveRepos(creator); err != nil {
+			problems = append(problems, err.Error())
+		}
+		if err == nil && len(repoList) == 0 {
+			problems = append(problems, "no repositories found")
+		}
+		if err := checkMaxRepos(len(repoList)); err != nil && viper.GetString("tracking-repo") == "" {
+			problems = append(problems, err.Error())
+		}
+
+		if org == "" && len(repoList) > 0 {
+			fmt.Fprintf(creator.out, "Resolved %d repositories; repository checks need --org\n", len(repoList))
+			repoList = nil
+		}
+		// owner/name entries are checked under their own owner, as create would use them
+		refs := make([]string, len(repoList))
+		for i, repo := range repoList {
+			refs[i] = repo
+			if !strings.Contains(repo, "/") {
+				refs[i] = org + "/" + repo
+			}
+		}
+		owners, byOwner, err := groupByOwner(refs)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		for _, owner := range owners {
+			// Cached milestones and assignees are keyed by repository name within one owner
+			creator.org = owner
+			creator.milestones = nil
+			creator.assignable = nil
+			for _, repo := range byOwner[owner] {
+				fmt.Fprintf(creator.out, "Checking %s/%s... ", owner, repo)
+				if err := creator.ValidateRepository(repo); err != nil {
+					fmt.Fprintf(creator.out, "%s (%v)\n", markFail(), err)
+					problems = append(problems, fmt.Sprintf("%s/%s: %v", owner, repo, err))
+					continue
+				}
+				fmt.Fprintln(creator.out, markOK())
+			}
+		}
+	}
+
+	fmt.Fprintln(out, "---")
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "No problems found")
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(out, "  - %s\n", problem)
+	}
+	fmt.Fprintf(out, "Summary: %d problems\n", len(problems))
+	// The problems are already listed, so the usage would only bury them
+	cmd.SilenceUsage = true
+	return fmt.Errorf("found %d problems", len(problems))
+}
+
+func init() {
+	addRepoFlags(validateCmd)
+	validateCmd.Flags().Int("max-repos", defaultMaxRepos, "Report more target repositories than this as a problem unless --confirm-large is given, like create (0 disables the cap)")
+	validateCmd.Flags().Bool("confirm-large", false, "Accept more target repositories than --max-repos")
+	validateCmd.Flags().String("from-manifest", "", "Check the manifest written by create --write-manifest instead of the content and repository settings")
+
+	rootCmd.AddCommand(validateCmd)
+}
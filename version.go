@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+)
+
+// Build information, set by release builds with
+// -ldflags "-X main.version=v1.2.3 -X main.commit=abc1234 -X main.date=2024-06-01T10:00:00Z"
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version and build information",
+	Run: func(cmd *cobra.Command, args []string) {
+		rev, built := buildInfo()
+		fmt.Printf("gitissuehelper %s\n", version)
+		fmt.Printf("Commit: %s\n", rev)
+		fmt.Printf("Built: %s\n", built)
+		fmt.Printf("go-github: %s\n", github.Version)
+		fmt.Printf("Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	},
+}
+
+// buildInfo returns the commit and build date, falling back to the VCS information
+// the Go toolchain embeds when they were not set with -ldflags
+func buildInfo() (string, string) {
+	rev, built := commit, date
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && rev == "":
+				rev = setting.Value
+			case setting.Key == "vcs.time" && built == "":
+				built = setting.Value
+			}
+		}
+	}
+	if rev == "" {
+		rev = "unknown"
+	}
+	if built == "" {
+		built = "unknown"
+	}
+	return rev, built
+}
+
+// defaultUserAgent identifies this tool and its version in API requests and audit logs
+func defaultUserAgent() string {
+	return "gitissuehelper/" + version
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// parseWorkflowRunURL extracts the owner, repository and run ID from a URL like
+// https://github.com/owner/repo/actions/runs/123 (an /attempts/N or /job/N suffix is allowed)
+func parseWorkflowRunURL(rawURL string) (string, string, int64, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", "", 0, fmt.Errorf("invalid workflow run URL %q", rawURL)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 5 || parts[2] != "actions" || parts[3] != "runs" {
+		return "", "", 0, fmt.Errorf("invalid workflow run URL %q: expected .../owner/repo/actions/runs/<id>", rawURL)
+	}
+	runID, err := strconv.ParseInt(parts[4], 10, 64)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid workflow run URL %q: run ID %q is not a number", rawURL, parts[4])
+	}
+	return parts[0], parts[1], runID, nil
+}
+
+// WorkflowFailureSummary renders a markdown summary of the failed jobs and steps of a workflow run
+func (ic *IssueCreator) WorkflowFailureSummary(rawURL string) (string, error) {
+	owner, repo, runID, err := parseWorkflowRunURL(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	run, _, err := ic.client.Actions.GetWorkflowRunByID(ic.ctx, owner, repo, runID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch workflow run %d in %s/%s: %w", runID, owner, repo, err)
+	}
+
+	opts := &github.ListWorkflowJobsOptions{Filter: "latest", ListOptions: ic.listOptions()}
+	var failedJobs []*github.WorkflowJob
+	for {
+		jobs, resp, err := ic.client.Actions.ListWorkflowJobs(ic.ctx, owner, repo, runID, opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to list jobs of workflow run %d in %s/%s: %w", runID, owner, repo, err)
+		}
+		for _, job := range jobs.Jobs {
+			if job.GetConclusion() == "failure" {
+				failedJobs = append(failedJobs, job)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Workflow failure\n\n")
+	fmt.Fprintf(&b, "[%s #%d](%s) on `%s` at %s concluded with **%s**.\n",
+		run.GetName(), run.GetRunNumber(), run.GetHTMLURL(), run.GetHeadBranch(), shortSHA(run.GetHeadSHA()), run.GetConclusion())
+
+	if len(failedJobs) == 0 {
+		b.WriteString("\nNo failed jobs were reported.\n")
+		return b.String(), nil
+	}
+
+	b.WriteString("\n| Job | Failed steps |\n| --- | --- |\n")
+	for _, job := range failedJobs {
+		var steps []string
+		for _, step := range job.Steps {
+			if step.GetConclusion() == "failure" {
+				steps = append(steps, step.GetName())
+			}
+		}
+		failedSteps := "-"
+		if len(steps) > 0 {
+			failedSteps = strings.Join(steps, ", ")
+		}
+		fmt.Fprintf(&b, "| [%s](%s) | %s |\n", job.GetName(), job.GetHTMLURL(), failedSteps)
+	}
+	return b.String(), nil
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}